@@ -1,8 +1,13 @@
 package cql
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/url"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,29 +24,67 @@ func NewClusterConfig(hosts ...string) *gocql.ClusterConfig {
 	return clusterConfig
 }
 
-// ClusterConfigToConfigString converts a gocql ClusterConfig to a config string
+// ClusterConfigToConfigString converts a gocql ClusterConfig to a config string,
+// settings with default values are left out. The hosts are first and the keys after them are sorted alphabetically,
+// so the output does not change when keys are added.
+// Panics if a setting has a value that can not be in a config string, like an unknown consistency,
+// use RoundTrip to get an error instead.
 // https://godoc.org/github.com/gocql/gocql#ClusterConfig
 func ClusterConfigToConfigString(clusterConfig *gocql.ClusterConfig) string {
+	configString, err := sortedConfigString(clusterConfig)
+	if err != nil {
+		panic(err)
+	}
+	return configString
+}
+
+// sortedConfigString converts a gocql ClusterConfig to a config string with the keys after the hosts sorted.
+// Returns an error if a setting has a value that can not be in a config string.
+func sortedConfigString(clusterConfig *gocql.ClusterConfig) (string, error) {
+	configString, err := clusterConfigToConfigString(clusterConfig)
+	if err != nil {
+		return "", err
+	}
+	return sortConfigString(configString), nil
+}
+
+// sortConfigString returns the config string with the keys after the hosts sorted
+func sortConfigString(configString string) string {
+	configStringSplit := strings.SplitN(configString, "?", 2)
+	if len(configStringSplit) < 2 || configStringSplit[1] == "" {
+		return configString
+	}
+	settings := strings.Split(configStringSplit[1], "&")
+	sort.Strings(settings)
+	return configStringSplit[0] + "?" + strings.Join(settings, "&")
+}
+
+// clusterConfigToConfigString converts a gocql ClusterConfig to a config string with the keys in the order they are added.
+// Returns an error if a setting has a value that can not be in a config string.
+func clusterConfigToConfigString(clusterConfig *gocql.ClusterConfig) (string, error) {
 	clusterConfigDefault := gocql.NewCluster()
 	stringConfig := strings.Join(clusterConfig.Hosts, ",") + "?"
 
 	if clusterConfig.Consistency != clusterConfigDefault.Consistency {
 		consistency, ok := DbConsistency[clusterConfig.Consistency]
 		if !ok {
-			panic(fmt.Sprint("clusterConfig.Consistency value not found in DbConsistency: ", clusterConfig.Consistency))
+			return "", fmt.Errorf("clusterConfig.Consistency value not found in DbConsistency: %v", clusterConfig.Consistency)
 		}
 		stringConfig += "consistency=" + consistency + "&"
 	}
-	if clusterConfig.Timeout >= 0 {
+	if clusterConfig.Timeout >= 0 && clusterConfig.Timeout != clusterConfigDefault.Timeout {
 		stringConfig += "timeout=" + clusterConfig.Timeout.String() + "&"
 	}
-	if clusterConfig.ConnectTimeout >= 0 {
+	if clusterConfig.ConnectTimeout >= 0 && clusterConfig.ConnectTimeout != clusterConfigDefault.ConnectTimeout {
 		stringConfig += "connectTimeout=" + clusterConfig.ConnectTimeout.String() + "&"
 	}
+	if clusterConfig.WriteTimeout > 0 && clusterConfig.WriteTimeout != clusterConfigDefault.WriteTimeout {
+		stringConfig += "writeTimeout=" + clusterConfig.WriteTimeout.String() + "&"
+	}
 	if clusterConfig.Keyspace != "" {
-		stringConfig += "keyspace=" + clusterConfig.Keyspace + "&"
+		stringConfig += "keyspace=" + url.QueryEscape(clusterConfig.Keyspace) + "&"
 	}
-	if clusterConfig.NumConns > 1 {
+	if clusterConfig.NumConns > 0 && clusterConfig.NumConns != clusterConfigDefault.NumConns {
 		stringConfig += "numConns=" + strconv.FormatInt(int64(clusterConfig.NumConns), 10) + "&"
 	}
 	if clusterConfig.IgnorePeerAddr != clusterConfigDefault.IgnorePeerAddr {
@@ -50,9 +93,118 @@ func ClusterConfigToConfigString(clusterConfig *gocql.ClusterConfig) string {
 	if clusterConfig.DisableInitialHostLookup != clusterConfigDefault.DisableInitialHostLookup {
 		stringConfig += "disableInitialHostLookup=" + fmt.Sprint(clusterConfig.DisableInitialHostLookup) + "&"
 	}
-	if clusterConfig.WriteCoalesceWaitTime != clusterConfigDefault.WriteCoalesceWaitTime {
+	if clusterConfig.Events.DisableNodeStatusEvents {
+		stringConfig += "disableNodeStatusEvents=true&"
+	}
+	if clusterConfig.Events.DisableTopologyEvents {
+		stringConfig += "disableTopologyEvents=true&"
+	}
+	if clusterConfig.Events.DisableSchemaEvents {
+		stringConfig += "disableSchemaEvents=true&"
+	}
+	// a wait time of 0 disables write coalescing, it is always added so it is kept whatever the gocql default is
+	if clusterConfig.WriteCoalesceWaitTime == 0 || clusterConfig.WriteCoalesceWaitTime != clusterConfigDefault.WriteCoalesceWaitTime {
 		stringConfig += "writeCoalesceWaitTime=" + fmt.Sprint(clusterConfig.WriteCoalesceWaitTime) + "&"
 	}
+	if clusterConfig.Port > 0 && clusterConfig.Port != clusterConfigDefault.Port {
+		stringConfig += "port=" + strconv.FormatInt(int64(clusterConfig.Port), 10) + "&"
+	}
+	if clusterConfig.ProtoVersion != 0 {
+		stringConfig += "protoVersion=" + strconv.Itoa(clusterConfig.ProtoVersion) + "&"
+	}
+	if clusterConfig.CQLVersion != "" && clusterConfig.CQLVersion != clusterConfigDefault.CQLVersion {
+		stringConfig += "cqlVersion=" + url.QueryEscape(clusterConfig.CQLVersion) + "&"
+	}
+	if clusterConfig.PageSize >= 0 && clusterConfig.PageSize != clusterConfigDefault.PageSize {
+		stringConfig += "pageSize=" + strconv.Itoa(clusterConfig.PageSize) + "&"
+	}
+	if clusterConfig.SerialConsistency != clusterConfigDefault.SerialConsistency {
+		serialConsistency, ok := DbSerialConsistency[clusterConfig.SerialConsistency]
+		if !ok {
+			return "", fmt.Errorf("clusterConfig.SerialConsistency value not found in DbSerialConsistency: %v", clusterConfig.SerialConsistency)
+		}
+		stringConfig += "serialConsistency=" + serialConsistency + "&"
+	}
+	if clusterConfig.DefaultTimestamp != clusterConfigDefault.DefaultTimestamp {
+		stringConfig += "defaultTimestamp=" + fmt.Sprint(clusterConfig.DefaultTimestamp) + "&"
+	}
+	if clusterConfig.ReconnectInterval >= 0 && clusterConfig.ReconnectInterval != clusterConfigDefault.ReconnectInterval {
+		stringConfig += "reconnectInterval=" + clusterConfig.ReconnectInterval.String() + "&"
+	}
+	if clusterConfig.MaxWaitSchemaAgreement >= 0 && clusterConfig.MaxWaitSchemaAgreement != clusterConfigDefault.MaxWaitSchemaAgreement {
+		stringConfig += "maxWaitSchemaAgreement=" + clusterConfig.MaxWaitSchemaAgreement.String() + "&"
+	}
+	if clusterConfig.SocketKeepalive > 0 {
+		stringConfig += "socketKeepalive=" + clusterConfig.SocketKeepalive.String() + "&"
+	}
+	if clusterConfig.MaxRoutingKeyInfo >= 0 && clusterConfig.MaxRoutingKeyInfo != clusterConfigDefault.MaxRoutingKeyInfo {
+		stringConfig += "maxRoutingKeyInfo=" + strconv.Itoa(clusterConfig.MaxRoutingKeyInfo) + "&"
+	}
+	if clusterConfig.DefaultIdempotence {
+		stringConfig += "defaultIdempotence=true&"
+	}
+	if clusterConfig.DisableSkipMetadata != clusterConfigDefault.DisableSkipMetadata {
+		stringConfig += "disableSkipMetadata=" + fmt.Sprint(clusterConfig.DisableSkipMetadata) + "&"
+	}
+	switch clusterConfig.Compressor.(type) {
+	case gocql.SnappyCompressor, *gocql.SnappyCompressor:
+		stringConfig += "compressor=snappy&"
+	}
+	if retryPolicy, ok := clusterConfig.RetryPolicy.(*gocql.SimpleRetryPolicy); ok {
+		stringConfig += "numRetries=" + strconv.Itoa(retryPolicy.NumRetries) + "&"
+	}
+	switch reconnectionPolicy := clusterConfig.ReconnectionPolicy.(type) {
+	case *gocql.ConstantReconnectionPolicy:
+		defaultPolicy, ok := clusterConfigDefault.ReconnectionPolicy.(*gocql.ConstantReconnectionPolicy)
+		if !ok || *reconnectionPolicy != *defaultPolicy {
+			stringConfig += "reconnectPolicy=constant&"
+			stringConfig += "reconnectMaxRetries=" + strconv.Itoa(reconnectionPolicy.MaxRetries) + "&"
+			stringConfig += "reconnectInitialInterval=" + reconnectionPolicy.Interval.String() + "&"
+		}
+	case *gocql.ExponentialReconnectionPolicy:
+		stringConfig += "reconnectPolicy=exponential&"
+		stringConfig += "reconnectMaxRetries=" + strconv.Itoa(reconnectionPolicy.MaxRetries) + "&"
+		stringConfig += "reconnectInitialInterval=" + reconnectionPolicy.InitialInterval.String() + "&"
+		if reconnectionPolicy.MaxInterval > 0 {
+			stringConfig += "reconnectMaxInterval=" + reconnectionPolicy.MaxInterval.String() + "&"
+		}
+	}
+	if policy, ok := clusterConfig.PoolConfig.HostSelectionPolicy.(*hostSelectionPolicy); ok {
+		if policy.name != "" {
+			stringConfig += "hostSelectionPolicy=" + policy.name + "&"
+		}
+		if policy.localDC != "" {
+			stringConfig += "localDC=" + url.QueryEscape(policy.localDC) + "&"
+		}
+		if policy.shuffleReplicas {
+			stringConfig += "shuffleReplicas=true&"
+		}
+		if policy.allowRemoteDCs {
+			stringConfig += "allowRemoteDCsForLocalConsistency=true&"
+		}
+	}
+	if translator, ok := clusterConfig.AddressTranslator.(*addressTranslator); ok && len(translator.internal) > 0 {
+		stringConfig += "addressTranslator=" + translator.String() + "&"
+	}
+	if dialer, ok := clusterConfig.Dialer.(*connectLimitDialer); ok {
+		stringConfig += "connectConcurrency=" + strconv.FormatInt(int64(dialer.limit), 10) + "&"
+	}
+	if filter, ok := clusterConfig.HostFilter.(*hostFilter); ok {
+		if len(filter.dataCentres) > 0 {
+			dataCentres := make([]string, len(filter.dataCentres))
+			for i := 0; i < len(filter.dataCentres); i++ {
+				dataCentres[i] = url.QueryEscape(filter.dataCentres[i])
+			}
+			stringConfig += "dcFilter=" + strings.Join(dataCentres, ",") + "&"
+		}
+		if len(filter.whiteList) > 0 {
+			whiteList := make([]string, len(filter.whiteList))
+			for i := 0; i < len(filter.whiteList); i++ {
+				whiteList[i] = filter.whiteList[i].String()
+			}
+			stringConfig += "hostFilter=whitelist:" + strings.Join(whiteList, ",") + "&"
+		}
+	}
 
 	if clusterConfig.Authenticator != nil {
 		passwordAuthenticator, ok := clusterConfig.Authenticator.(gocql.PasswordAuthenticator)
@@ -64,13 +216,22 @@ func ClusterConfigToConfigString(clusterConfig *gocql.ClusterConfig) string {
 				stringConfig += "password=" + url.QueryEscape(passwordAuthenticator.Password) + "&"
 			}
 		}
+		dseAuthenticator, ok := clusterConfig.Authenticator.(DsePlainTextAuthenticator)
+		if ok {
+			if dseAuthenticator.Username != "" {
+				stringConfig += "username=" + url.QueryEscape(dseAuthenticator.Username) + "&"
+			}
+			if dseAuthenticator.Password != "" {
+				stringConfig += "password=" + url.QueryEscape(dseAuthenticator.Password) + "&"
+			}
+			stringConfig += "dseAuth=plainText&"
+		}
 	}
 
 	if sslOpts := clusterConfig.SslOpts; sslOpts != nil {
 		defaultSslOpts := gocql.SslOptions{}
-		if s := strconv.FormatBool(sslOpts.EnableHostVerification); sslOpts.EnableHostVerification != defaultSslOpts.EnableHostVerification {
-			stringConfig += "enableHostVerification=" + s + "&"
-		}
+		// enableHostVerification is always added so SslOpts is not lost when no other SSL fields are set
+		stringConfig += "enableHostVerification=" + strconv.FormatBool(sslOpts.EnableHostVerification) + "&"
 		if s := sslOpts.KeyPath; sslOpts.KeyPath != defaultSslOpts.KeyPath {
 			stringConfig += "keyPath=" + url.QueryEscape(s) + "&"
 		}
@@ -80,50 +241,142 @@ func ClusterConfigToConfigString(clusterConfig *gocql.ClusterConfig) string {
 		if s := sslOpts.CaPath; sslOpts.CaPath != defaultSslOpts.CaPath {
 			stringConfig += "caPath=" + url.QueryEscape(s) + "&"
 		}
+		if sslOpts.Config != nil {
+			stringConfig += "sslInsecureSkipVerify=" + strconv.FormatBool(sslOpts.InsecureSkipVerify) + "&"
+		}
+		if sslOpts.Config != nil && sslOpts.MinVersion != 0 {
+			sslMinVersion, ok := DbSslVersion[sslOpts.MinVersion]
+			if !ok {
+				return "", fmt.Errorf("clusterConfig.SslOpts.MinVersion value not found in DbSslVersion: %v", sslOpts.MinVersion)
+			}
+			stringConfig += "sslMinVersion=" + sslMinVersion + "&"
+		}
 	}
 
-	return stringConfig[:len(stringConfig)-1]
+	return stringConfig[:len(stringConfig)-1], nil
+}
+
+// RoundTrip converts a gocql ClusterConfig to a config string and back to a gocql ClusterConfig.
+// Returns an error if the result is not equivalent to the original ClusterConfig,
+// like when the ClusterConfig has settings that are not supported in config strings.
+func RoundTrip(clusterConfig *gocql.ClusterConfig) error {
+	configString, err := sortedConfigString(clusterConfig)
+	if err != nil {
+		return fmt.Errorf("ClusterConfigToConfigString error: %v", err)
+	}
+	roundTripClusterConfig, err := ConfigStringToClusterConfig(configString)
+	if err != nil {
+		return fmt.Errorf("ConfigStringToClusterConfig error: %v", err)
+	}
+	if !reflect.DeepEqual(roundTripClusterConfig, clusterConfig) {
+		return fmt.Errorf("round trip not equivalent for: %v", configString)
+	}
+	return nil
 }
 
-// ConfigStringToClusterConfig converts a config string to a gocql ClusterConfig
+// ConfigStringToClusterConfig converts a config string to a gocql ClusterConfig.
+// When caPath or certPath is set, enableHostVerification defaults to true,
+// unless enableHostVerification=false or sslInsecureSkipVerify=true is set.
 func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, error) {
 	clusterConfig := NewClusterConfig()
 	configStringSplit := strings.SplitN(configString, "?", 2)
+	if strings.ContainsAny(configStringSplit[0], "&=") {
+		return nil, fmt.Errorf("invalid hosts, missing ?: %v", configStringSplit[0])
+	}
 
+	// hostsPort is the port from host:port entries, all hosts with a port need to use the same port
+	var hostsPort int
 	if len(configStringSplit[0]) > 1 {
 		hostsSplit := strings.Split(configStringSplit[0], ",")
 		if len(hostsSplit) > 0 {
 			clusterConfig.Hosts = make([]string, len(hostsSplit))
 			for i := 0; i < len(hostsSplit); i++ {
-				clusterConfig.Hosts[i] = strings.TrimSpace(hostsSplit[i])
+				host, port, err := splitHostPort(strings.TrimSpace(hostsSplit[i]))
+				if err != nil {
+					return nil, err
+				}
+				if port > 0 {
+					if hostsPort > 0 && port != hostsPort {
+						return nil, fmt.Errorf("hosts have different ports: %v and %v", hostsPort, port)
+					}
+					hostsPort = port
+				}
+				clusterConfig.Hosts[i] = host
+			}
+			if hostsPort > 0 {
+				clusterConfig.Port = hostsPort
 			}
 		}
 	}
 
 	passwordAuthenticator := gocql.PasswordAuthenticator{}
+	var dseAuth bool
+	// noKeyspace is set by noKeyspace=true to connect without a keyspace on purpose
+	var noKeyspace bool
 	sslOpts := gocql.SslOptions{}
+	var enableHostVerificationSet bool
+	var sslInsecureSkipVerifySet bool
+
+	// reconnection policy settings are applied after all keys are parsed
+	// so that they can be given in any order
+	var reconnectPolicy string
+	var reconnectPolicySet bool
+	var reconnectMaxInterval time.Duration
+	reconnectMaxRetries, reconnectInitialInterval := 3, time.Second
+	if defaultPolicy, ok := clusterConfig.ReconnectionPolicy.(*gocql.ConstantReconnectionPolicy); ok {
+		reconnectMaxRetries, reconnectInitialInterval = defaultPolicy.MaxRetries, defaultPolicy.Interval
+	}
+
+	var filter *hostFilter
+
+	// host selection policy settings are also applied after all keys are parsed
+	var hostSelectionPolicyName string
+	var localDC string
+	var shuffleReplicas bool
+	var allowRemoteDCs bool
+
+	// connectConcurrency dialer is created after all keys are parsed to use connectTimeout and socketKeepalive
+	var connectConcurrency int
 
 	if len(configStringSplit) > 1 && len(configStringSplit[1]) > 1 {
 		dataSplit := strings.Split(configStringSplit[1], "&")
 		if len(dataSplit) > 0 {
+			keys := make(map[string]struct{}, len(dataSplit))
 			for i := 0; i < len(dataSplit); i++ {
 				settingSplit := strings.SplitN(dataSplit[i], "=", 2)
 				if len(settingSplit) != 2 {
 					return nil, fmt.Errorf("missing =")
 				}
 				key, value := strings.TrimSpace(settingSplit[0]), settingSplit[1]
+				if _, ok := keys[key]; ok {
+					return nil, fmt.Errorf("duplicate key: %v", key)
+				}
+				keys[key] = struct{}{}
 				switch key {
 				case "consistency":
 					consistency, ok := DbConsistencyLevels[value]
 					if !ok {
+						if _, ok = DbSerialConsistencyLevels[value]; ok {
+							return nil, fmt.Errorf("failed for: %v = %v, %v is a serial consistency, use serialConsistency=%v", key, value, value, value)
+						}
 						return nil, fmt.Errorf("failed for: %v = %v", key, value)
 					}
 					clusterConfig.Consistency = gocql.Consistency(consistency)
 				case "keyspace":
-					if value == "" {
+					data, err := url.QueryUnescape(value)
+					if err != nil || data == "" {
 						return nil, fmt.Errorf("failed for: %v = %v", key, value)
 					}
-					clusterConfig.Keyspace = value
+					clusterConfig.Keyspace = data
+				case "appName", "appVersion":
+					// protocol v5 STARTUP options APPLICATION_NAME and APPLICATION_VERSION, gocql does not expose the STARTUP options
+					return nil, fmt.Errorf("failed for: %v = %v, application startup options are not supported by gocql", key, value)
+				case "noKeyspace":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					noKeyspace = data
 				case "timeout":
 					data, err := time.ParseDuration(value)
 					if err != nil {
@@ -140,6 +393,14 @@ func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, err
 					if data >= 0 {
 						clusterConfig.ConnectTimeout = data
 					}
+				case "writeTimeout":
+					data, err := time.ParseDuration(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if data >= 0 {
+						clusterConfig.WriteTimeout = data
+					}
 				case "numConns":
 					data, err := strconv.ParseInt(value, 10, 64)
 					if err != nil {
@@ -148,6 +409,12 @@ func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, err
 					if data > 0 {
 						clusterConfig.NumConns = int(data)
 					}
+				case "connectConcurrency":
+					data, err := strconv.ParseInt(value, 10, 64)
+					if err != nil || data < 1 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					connectConcurrency = int(data)
 				case "ignorePeerAddr":
 					data, err := strconv.ParseBool(value)
 					if err != nil {
@@ -160,12 +427,211 @@ func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, err
 						return nil, fmt.Errorf("failed for: %v = %v", key, value)
 					}
 					clusterConfig.DisableInitialHostLookup = data
+				case "disableNodeStatusEvents":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.Events.DisableNodeStatusEvents = data
+				case "disableTopologyEvents":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.Events.DisableTopologyEvents = data
+				case "disableSchemaEvents":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.Events.DisableSchemaEvents = data
 				case "writeCoalesceWaitTime":
 					data, err := time.ParseDuration(value)
 					if err != nil {
 						return nil, fmt.Errorf("failed for: %v = %v", key, value)
 					}
 					clusterConfig.WriteCoalesceWaitTime = data
+				case "port":
+					data, err := strconv.ParseInt(value, 10, 64)
+					if err != nil || data < 1 || data > 65535 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if hostsPort > 0 && int(data) != hostsPort {
+						return nil, fmt.Errorf("port %v does not match hosts port %v", data, hostsPort)
+					}
+					clusterConfig.Port = int(data)
+				case "protoVersion":
+					data, err := strconv.Atoi(value)
+					if err != nil || data < 1 || data > 5 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.ProtoVersion = data
+				case "cqlVersion":
+					data, err := url.QueryUnescape(value)
+					data = strings.TrimSpace(data)
+					if err != nil || data == "" {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.CQLVersion = data
+				case "pageSize":
+					data, err := strconv.Atoi(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.PageSize = data
+				case "serialConsistency":
+					serialConsistency, ok := DbSerialConsistencyLevels[value]
+					if !ok {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.SerialConsistency = serialConsistency
+				case "defaultTimestamp":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.DefaultTimestamp = data
+				case "reconnectInterval":
+					data, err := time.ParseDuration(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if data >= 0 {
+						clusterConfig.ReconnectInterval = data
+					}
+				case "maxWaitSchemaAgreement":
+					data, err := time.ParseDuration(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.MaxWaitSchemaAgreement = data
+				case "socketKeepalive":
+					data, err := time.ParseDuration(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if data >= 0 {
+						clusterConfig.SocketKeepalive = data
+					}
+				case "maxRoutingKeyInfo":
+					data, err := strconv.Atoi(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.MaxRoutingKeyInfo = data
+				case "defaultIdempotence":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.DefaultIdempotence = data
+				case "disableSkipMetadata":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.DisableSkipMetadata = data
+				case "compressor":
+					switch value {
+					case "snappy":
+						clusterConfig.Compressor = gocql.SnappyCompressor{}
+					default:
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+				case "numRetries":
+					data, err := strconv.Atoi(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.RetryPolicy = &gocql.SimpleRetryPolicy{NumRetries: data}
+				case "reconnectPolicy":
+					if value != "constant" && value != "exponential" {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					reconnectPolicy = value
+					reconnectPolicySet = true
+				case "reconnectMaxRetries":
+					data, err := strconv.Atoi(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					reconnectMaxRetries = data
+					reconnectPolicySet = true
+				case "reconnectInitialInterval":
+					data, err := time.ParseDuration(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					reconnectInitialInterval = data
+					reconnectPolicySet = true
+				case "reconnectMaxInterval":
+					data, err := time.ParseDuration(value)
+					if err != nil || data < 0 {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					reconnectMaxInterval = data
+					reconnectPolicySet = true
+				case "hostSelectionPolicy":
+					switch value {
+					case "roundrobin", "tokenaware", "tokenaware,dcaware":
+					default:
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					hostSelectionPolicyName = value
+				case "localDC":
+					data, err := url.QueryUnescape(value)
+					if err != nil || data == "" {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					localDC = data
+				case "shuffleReplicas":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					shuffleReplicas = data
+				case "allowRemoteDCsForLocalConsistency":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					allowRemoteDCs = data
+				case "addressTranslator":
+					translator, err := newAddressTranslator(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					clusterConfig.AddressTranslator = translator
+				case "dcFilter":
+					if filter == nil {
+						filter = &hostFilter{}
+					}
+					dataCentres := strings.Split(value, ",")
+					for i := 0; i < len(dataCentres); i++ {
+						data, err := url.QueryUnescape(strings.TrimSpace(dataCentres[i]))
+						if err != nil || data == "" {
+							return nil, fmt.Errorf("failed for: %v = %v", key, value)
+						}
+						filter.dataCentres = append(filter.dataCentres, data)
+					}
+					clusterConfig.HostFilter = filter
+				case "hostFilter":
+					filterSplit := strings.SplitN(value, ":", 2)
+					if len(filterSplit) != 2 || filterSplit[0] != "whitelist" {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if filter == nil {
+						filter = &hostFilter{}
+					}
+					whiteList := strings.Split(filterSplit[1], ",")
+					for i := 0; i < len(whiteList); i++ {
+						ip := net.ParseIP(strings.TrimSpace(whiteList[i]))
+						if ip == nil {
+							return nil, fmt.Errorf("failed for: %v = %v", key, value)
+						}
+						filter.whiteList = append(filter.whiteList, ip)
+					}
+					clusterConfig.HostFilter = filter
 				case "username":
 					data, err := url.QueryUnescape(value)
 					if err != nil {
@@ -180,12 +646,22 @@ func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, err
 					}
 					passwordAuthenticator.Password = data
 					clusterConfig.Authenticator = passwordAuthenticator
+				case "dseAuth":
+					switch value {
+					case "plainText":
+						dseAuth = true
+					case "gssapi":
+						return nil, fmt.Errorf("dseAuth gssapi is not supported, Kerberos libraries are not available")
+					default:
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
 				case "enableHostVerification":
 					data, err := strconv.ParseBool(value)
 					if err != nil {
 						return nil, fmt.Errorf("failed for: %v = %v", key, value)
 					}
 					sslOpts.EnableHostVerification = data
+					enableHostVerificationSet = true
 					clusterConfig.SslOpts = &sslOpts
 				case "certPath":
 					data, err := url.QueryUnescape(value)
@@ -208,6 +684,27 @@ func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, err
 					}
 					sslOpts.CaPath = data
 					clusterConfig.SslOpts = &sslOpts
+				case "sslInsecureSkipVerify":
+					data, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if sslOpts.Config == nil {
+						sslOpts.Config = &tls.Config{}
+					}
+					sslOpts.InsecureSkipVerify = data
+					sslInsecureSkipVerifySet = true
+					clusterConfig.SslOpts = &sslOpts
+				case "sslMinVersion":
+					sslMinVersion, ok := DbSslVersions[value]
+					if !ok {
+						return nil, fmt.Errorf("failed for: %v = %v", key, value)
+					}
+					if sslOpts.Config == nil {
+						sslOpts.Config = &tls.Config{}
+					}
+					sslOpts.MinVersion = sslMinVersion
+					clusterConfig.SslOpts = &sslOpts
 				default:
 					return nil, fmt.Errorf("invalid key: %v", key)
 				}
@@ -215,5 +712,538 @@ func ConfigStringToClusterConfig(configString string) (*gocql.ClusterConfig, err
 		}
 	}
 
+	// host verification is on by default with a CA or certificate path, unless host verification is turned off
+	if clusterConfig.SslOpts != nil && !enableHostVerificationSet && (sslOpts.CaPath != "" || sslOpts.CertPath != "") &&
+		(sslOpts.Config == nil || !sslOpts.InsecureSkipVerify) {
+		sslOpts.EnableHostVerification = true
+	}
+	// gocql verifies the host with a tls.Config that does not skip verify, so a tls.Config made only for sslMinVersion
+	// skips verify when host verification is off
+	if sslOpts.Config != nil && !sslInsecureSkipVerifySet {
+		sslOpts.InsecureSkipVerify = !sslOpts.EnableHostVerification
+	}
+
+	if noKeyspace && clusterConfig.Keyspace != "" {
+		return nil, fmt.Errorf("noKeyspace=true can not be used with keyspace = %v", clusterConfig.Keyspace)
+	}
+
+	if dseAuth {
+		clusterConfig.Authenticator = DsePlainTextAuthenticator{
+			Username: passwordAuthenticator.Username,
+			Password: passwordAuthenticator.Password,
+		}
+	}
+
+	if reconnectPolicySet {
+		if reconnectPolicy == "exponential" {
+			clusterConfig.ReconnectionPolicy = &gocql.ExponentialReconnectionPolicy{
+				MaxRetries:      reconnectMaxRetries,
+				InitialInterval: reconnectInitialInterval,
+				MaxInterval:     reconnectMaxInterval,
+			}
+		} else {
+			if reconnectMaxInterval != 0 {
+				return nil, fmt.Errorf("reconnectMaxInterval requires reconnectPolicy=exponential")
+			}
+			clusterConfig.ReconnectionPolicy = &gocql.ConstantReconnectionPolicy{
+				MaxRetries: reconnectMaxRetries,
+				Interval:   reconnectInitialInterval,
+			}
+		}
+	}
+
+	if hostSelectionPolicyName != "" || localDC != "" || shuffleReplicas || allowRemoteDCs {
+		policy, err := newHostSelectionPolicy(hostSelectionPolicyName, localDC, shuffleReplicas, allowRemoteDCs)
+		if err != nil {
+			return nil, err
+		}
+		clusterConfig.PoolConfig.HostSelectionPolicy = policy
+	}
+
+	if connectConcurrency > 0 {
+		// the same dialer gocql uses when there is no Dialer
+		dialer := &net.Dialer{Timeout: clusterConfig.ConnectTimeout}
+		if clusterConfig.SocketKeepalive > 0 {
+			dialer.KeepAlive = clusterConfig.SocketKeepalive
+		}
+		clusterConfig.Dialer = &connectLimitDialer{dialer: dialer, limit: connectConcurrency}
+	}
+
 	return clusterConfig, nil
 }
+
+// ParseDSN parses a config string into a DSN.
+// Settings without a DSN field are kept and used by ClusterConfig.
+func ParseDSN(configString string) (*DSN, error) {
+	clusterConfig, err := ConfigStringToClusterConfig(configString)
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := &DSN{
+		Hosts:             clusterConfig.Hosts,
+		Port:              clusterConfig.Port,
+		Keyspace:          clusterConfig.Keyspace,
+		Consistency:       clusterConfig.Consistency,
+		SerialConsistency: clusterConfig.SerialConsistency,
+		Timeout:           clusterConfig.Timeout,
+		ConnectTimeout:    clusterConfig.ConnectTimeout,
+		WriteTimeout:      clusterConfig.WriteTimeout,
+		NumConns:          clusterConfig.NumConns,
+		PageSize:          clusterConfig.PageSize,
+		ProtoVersion:      clusterConfig.ProtoVersion,
+		clusterConfig:     clusterConfig,
+	}
+	switch authenticator := clusterConfig.Authenticator.(type) {
+	case gocql.PasswordAuthenticator:
+		dsn.Username = authenticator.Username
+		dsn.Password = authenticator.Password
+	case DsePlainTextAuthenticator:
+		dsn.Username = authenticator.Username
+		dsn.Password = authenticator.Password
+	}
+
+	return dsn, nil
+}
+
+// ClusterConfig returns a new gocql ClusterConfig from the DSN.
+// Fields with zero values are not applied, so they keep the settings of the config string parsed by ParseDSN,
+// or the NewClusterConfig defaults. Empty Username and Password remove a password authenticator.
+// Each ClusterConfig has its own host selection policy, so each can create a session.
+func (dsn *DSN) ClusterConfig() *gocql.ClusterConfig {
+	clusterConfig := NewClusterConfig()
+	if dsn.clusterConfig != nil {
+		clusterConfig = sessionClusterConfig(dsn.clusterConfig)
+	}
+
+	if len(dsn.Hosts) > 0 {
+		clusterConfig.Hosts = append([]string(nil), dsn.Hosts...)
+	}
+	if dsn.Port != 0 {
+		clusterConfig.Port = dsn.Port
+	}
+	if dsn.Keyspace != "" {
+		clusterConfig.Keyspace = dsn.Keyspace
+	}
+	if dsn.Consistency != 0 {
+		clusterConfig.Consistency = dsn.Consistency
+	}
+	if dsn.SerialConsistency != 0 {
+		clusterConfig.SerialConsistency = dsn.SerialConsistency
+	}
+	if dsn.Timeout != 0 {
+		clusterConfig.Timeout = dsn.Timeout
+	}
+	if dsn.ConnectTimeout != 0 {
+		clusterConfig.ConnectTimeout = dsn.ConnectTimeout
+	}
+	if dsn.WriteTimeout != 0 {
+		clusterConfig.WriteTimeout = dsn.WriteTimeout
+	}
+	if dsn.NumConns != 0 {
+		clusterConfig.NumConns = dsn.NumConns
+	}
+	if dsn.PageSize != 0 {
+		clusterConfig.PageSize = dsn.PageSize
+	}
+	if dsn.ProtoVersion != 0 {
+		clusterConfig.ProtoVersion = dsn.ProtoVersion
+	}
+
+	_, isDse := clusterConfig.Authenticator.(DsePlainTextAuthenticator)
+	switch {
+	case isDse:
+		clusterConfig.Authenticator = DsePlainTextAuthenticator{Username: dsn.Username, Password: dsn.Password}
+	case dsn.Username != "" || dsn.Password != "":
+		clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: dsn.Username, Password: dsn.Password}
+	default:
+		if _, ok := clusterConfig.Authenticator.(gocql.PasswordAuthenticator); ok {
+			clusterConfig.Authenticator = nil
+		}
+	}
+
+	return clusterConfig
+}
+
+// String returns the DSN as a normalized config string, settings with default values are left out and the keys are sorted.
+// The password is redacted as xxxxx so the DSN can be logged, use ClusterConfigToConfigString with ClusterConfig for the password.
+// Returns an empty string if a setting has a value that can not be in a config string, like an unknown consistency.
+func (dsn *DSN) String() string {
+	configString, err := sortedConfigString(dsn.ClusterConfig())
+	if err != nil {
+		return ""
+	}
+	return redactPassword(configString)
+}
+
+// Equal returns true if the DSN and other have the same settings, including the password,
+// regardless of the key order and of settings set to default values in their config strings
+func (dsn *DSN) Equal(other *DSN) bool {
+	if dsn == nil || other == nil {
+		return dsn == other
+	}
+	configString, err := sortedConfigString(dsn.ClusterConfig())
+	if err != nil {
+		return false
+	}
+	otherConfigString, err := sortedConfigString(other.ClusterConfig())
+	if err != nil {
+		return false
+	}
+	return configString == otherConfigString
+}
+
+// redactPassword returns the config string with the password value replaced by xxxxx
+func redactPassword(configString string) string {
+	configStringSplit := strings.SplitN(configString, "?", 2)
+	if len(configStringSplit) < 2 {
+		return configString
+	}
+	settings := strings.Split(configStringSplit[1], "&")
+	for i := 0; i < len(settings); i++ {
+		if strings.HasPrefix(settings[i], "password=") {
+			settings[i] = "password=xxxxx"
+		}
+	}
+	return configStringSplit[0] + "?" + strings.Join(settings, "&")
+}
+
+// NewConfigBuilder returns a new ConfigBuilder with the default settings of NewClusterConfig.
+// Build returns the config string, made by ClusterConfigToConfigString, or an error if the settings are not valid.
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{clusterConfig: NewClusterConfig()}
+}
+
+// Hosts sets the hosts
+func (configBuilder *ConfigBuilder) Hosts(hosts ...string) *ConfigBuilder {
+	configBuilder.clusterConfig.Hosts = append([]string(nil), hosts...)
+	return configBuilder
+}
+
+// Port sets the port of the hosts
+func (configBuilder *ConfigBuilder) Port(port int) *ConfigBuilder {
+	configBuilder.clusterConfig.Port = port
+	return configBuilder
+}
+
+// Keyspace sets the keyspace
+func (configBuilder *ConfigBuilder) Keyspace(keyspace string) *ConfigBuilder {
+	configBuilder.clusterConfig.Keyspace = keyspace
+	return configBuilder
+}
+
+// Consistency sets the consistency, which needs to be in DbConsistency
+func (configBuilder *ConfigBuilder) Consistency(consistency gocql.Consistency) *ConfigBuilder {
+	configBuilder.clusterConfig.Consistency = consistency
+	return configBuilder
+}
+
+// SerialConsistency sets the serial consistency, which needs to be in DbSerialConsistency
+func (configBuilder *ConfigBuilder) SerialConsistency(serialConsistency gocql.SerialConsistency) *ConfigBuilder {
+	configBuilder.clusterConfig.SerialConsistency = serialConsistency
+	return configBuilder
+}
+
+// Timeout sets the query timeout
+func (configBuilder *ConfigBuilder) Timeout(timeout time.Duration) *ConfigBuilder {
+	configBuilder.clusterConfig.Timeout = timeout
+	return configBuilder
+}
+
+// ConnectTimeout sets the connection timeout
+func (configBuilder *ConfigBuilder) ConnectTimeout(connectTimeout time.Duration) *ConfigBuilder {
+	configBuilder.clusterConfig.ConnectTimeout = connectTimeout
+	return configBuilder
+}
+
+// NumConns sets the number of connections per host
+func (configBuilder *ConfigBuilder) NumConns(numConns int) *ConfigBuilder {
+	configBuilder.clusterConfig.NumConns = numConns
+	return configBuilder
+}
+
+// PageSize sets the default page size
+func (configBuilder *ConfigBuilder) PageSize(pageSize int) *ConfigBuilder {
+	configBuilder.clusterConfig.PageSize = pageSize
+	return configBuilder
+}
+
+// ProtoVersion sets the protocol version
+func (configBuilder *ConfigBuilder) ProtoVersion(protoVersion int) *ConfigBuilder {
+	configBuilder.clusterConfig.ProtoVersion = protoVersion
+	return configBuilder
+}
+
+// WithAuth sets the username and password
+func (configBuilder *ConfigBuilder) WithAuth(username string, password string) *ConfigBuilder {
+	configBuilder.clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: username, Password: password}
+	return configBuilder
+}
+
+// WithSSL sets the SSL CA, certificate, and key paths, empty paths are not set, and if the host is verified
+func (configBuilder *ConfigBuilder) WithSSL(caPath string, certPath string, keyPath string, enableHostVerification bool) *ConfigBuilder {
+	configBuilder.clusterConfig.SslOpts = &gocql.SslOptions{
+		CaPath:                 caPath,
+		CertPath:               certPath,
+		KeyPath:                keyPath,
+		EnableHostVerification: enableHostVerification,
+	}
+	return configBuilder
+}
+
+// Build returns the config string.
+// Returns an error if a setting can not be in a config string or the config string is not valid, like a port over 65535.
+func (configBuilder *ConfigBuilder) Build() (string, error) {
+	configString, err := sortedConfigString(configBuilder.clusterConfig)
+	if err != nil {
+		return "", err
+	}
+	_, err = ConfigStringToClusterConfig(configString)
+	if err != nil {
+		return "", err
+	}
+	return configString, nil
+}
+
+// splitHostPort splits a host:port entry into host and port.
+// IPv6 addresses with a port need to be in brackets, like [2001:db8::1]:9042.
+// IP addresses are returned in canonical form. Returns a port of 0 if the entry has no port.
+func splitHostPort(hostPort string) (string, int, error) {
+	host, portString, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		// no port, like a host name or an IPv6 address with or without brackets
+		host = hostPort
+		if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+			host = host[1 : len(host)-1]
+		}
+		return canonicalHost(host), 0, nil
+	}
+	port, err := strconv.ParseInt(portString, 10, 64)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port for host: %v", hostPort)
+	}
+	return canonicalHost(host), int(port), nil
+}
+
+// canonicalHost returns IP addresses in canonical form and host names unchanged
+func canonicalHost(host string) string {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+	return ip.String()
+}
+
+// newHostSelectionPolicy returns a new host selection policy from config string settings.
+// shuffleReplicas is only supported by the token aware policies.
+// allowRemoteDCs, trying the remote DC replicas when the local DC replicas are down, is only supported by tokenaware,dcaware,
+// the plain DC aware policy already falls back to remote DC hosts after the local DC hosts.
+func newHostSelectionPolicy(name string, localDC string, shuffleReplicas bool, allowRemoteDCs bool) (*hostSelectionPolicy, error) {
+	policy := &hostSelectionPolicy{
+		name:            name,
+		localDC:         localDC,
+		shuffleReplicas: shuffleReplicas,
+		allowRemoteDCs:  allowRemoteDCs,
+	}
+
+	if shuffleReplicas && name != "tokenaware" && name != "tokenaware,dcaware" {
+		return nil, fmt.Errorf("shuffleReplicas not supported for hostSelectionPolicy: %v", name)
+	}
+	if allowRemoteDCs && name != "tokenaware,dcaware" {
+		return nil, fmt.Errorf("allowRemoteDCsForLocalConsistency not supported for hostSelectionPolicy: %v", name)
+	}
+
+	switch name {
+	case "roundrobin":
+		policy.HostSelectionPolicy = gocql.RoundRobinHostPolicy()
+	case "tokenaware":
+		policy.HostSelectionPolicy = tokenAwareHostPolicy(gocql.RoundRobinHostPolicy(), shuffleReplicas, false)
+	case "tokenaware,dcaware":
+		if localDC == "" {
+			return nil, fmt.Errorf("localDC required for hostSelectionPolicy: %v", name)
+		}
+		policy.HostSelectionPolicy = tokenAwareHostPolicy(gocql.DCAwareRoundRobinPolicy(localDC), shuffleReplicas, allowRemoteDCs)
+		return policy, nil
+	case "":
+		// localDC without a hostSelectionPolicy is a plain DC aware round robin policy
+		policy.HostSelectionPolicy = gocql.DCAwareRoundRobinPolicy(localDC)
+		return policy, nil
+	default:
+		return nil, fmt.Errorf("invalid hostSelectionPolicy: %v", name)
+	}
+
+	if localDC != "" {
+		return nil, fmt.Errorf("localDC not supported for hostSelectionPolicy: %v", name)
+	}
+
+	return policy, nil
+}
+
+// tokenAwareHostPolicy returns a gocql token aware policy with fallback, gocql does not export its option type.
+// nonLocalReplicasFallback tries the remote DC replicas when the local DC replicas are down.
+func tokenAwareHostPolicy(fallback gocql.HostSelectionPolicy, shuffleReplicas bool, nonLocalReplicasFallback bool) gocql.HostSelectionPolicy {
+	switch {
+	case shuffleReplicas && nonLocalReplicasFallback:
+		return gocql.TokenAwareHostPolicy(fallback, gocql.ShuffleReplicas(), gocql.NonLocalReplicasFallback())
+	case shuffleReplicas:
+		return gocql.TokenAwareHostPolicy(fallback, gocql.ShuffleReplicas())
+	case nonLocalReplicasFallback:
+		return gocql.TokenAwareHostPolicy(fallback, gocql.NonLocalReplicasFallback())
+	}
+	return gocql.TokenAwareHostPolicy(fallback)
+}
+
+// newSessionPolicy returns a new policy with the same settings.
+// gocql token aware policies can not be shared between sessions, so each session needs its own policy.
+func (policy *hostSelectionPolicy) newSessionPolicy() *hostSelectionPolicy {
+	newPolicy, err := newHostSelectionPolicy(policy.name, policy.localDC, policy.shuffleReplicas, policy.allowRemoteDCs)
+	if err != nil {
+		// the settings were checked when the policy was created
+		return policy
+	}
+	return newPolicy
+}
+
+// sessionClusterConfig returns a copy of clusterConfig for creating a session,
+// with a new host selection policy when the policy was built from a config string
+func sessionClusterConfig(clusterConfig *gocql.ClusterConfig) *gocql.ClusterConfig {
+	newClusterConfig := *clusterConfig
+	if policy, ok := clusterConfig.PoolConfig.HostSelectionPolicy.(*hostSelectionPolicy); ok {
+		newClusterConfig.PoolConfig.HostSelectionPolicy = policy.newSessionPolicy()
+	}
+	return &newClusterConfig
+}
+
+// AddHosts adds hosts to the wrapped policy, in bulk if the wrapped policy supports it
+func (policy *hostSelectionPolicy) AddHosts(hosts []*gocql.HostInfo) {
+	bulkPolicy, ok := policy.HostSelectionPolicy.(interface {
+		AddHosts([]*gocql.HostInfo)
+	})
+	if ok {
+		bulkPolicy.AddHosts(hosts)
+		return
+	}
+	for i := 0; i < len(hosts); i++ {
+		policy.HostSelectionPolicy.AddHost(hosts[i])
+	}
+}
+
+// newAddressTranslator returns a new address translator from comma separated internal:external pairs.
+// IPv6 addresses need to be in brackets, like [fd00::1]:[2001:db8::1]
+func newAddressTranslator(pairs string) (*addressTranslator, error) {
+	translator := &addressTranslator{}
+	pairsSplit := strings.Split(pairs, ",")
+	for i := 0; i < len(pairsSplit); i++ {
+		internal, external, err := splitAddressPair(strings.TrimSpace(pairsSplit[i]))
+		if err != nil {
+			return nil, err
+		}
+		translator.internal = append(translator.internal, internal)
+		translator.external = append(translator.external, external)
+	}
+	return translator, nil
+}
+
+// splitAddressPair splits an internal:external address pair
+func splitAddressPair(pair string) (net.IP, net.IP, error) {
+	var internal, external string
+	if strings.HasPrefix(pair, "[") {
+		index := strings.Index(pair, "]:")
+		if index < 0 {
+			return nil, nil, fmt.Errorf("invalid address pair: %v", pair)
+		}
+		internal, external = pair[1:index], pair[index+2:]
+	} else {
+		pairSplit := strings.SplitN(pair, ":", 2)
+		if len(pairSplit) != 2 {
+			return nil, nil, fmt.Errorf("invalid address pair: %v", pair)
+		}
+		internal, external = pairSplit[0], pairSplit[1]
+	}
+	if strings.HasPrefix(external, "[") && strings.HasSuffix(external, "]") {
+		external = external[1 : len(external)-1]
+	}
+
+	internalIP, externalIP := net.ParseIP(internal), net.ParseIP(external)
+	if internalIP == nil || externalIP == nil {
+		return nil, nil, fmt.Errorf("invalid address pair: %v", pair)
+	}
+	return internalIP, externalIP, nil
+}
+
+// Translate returns the external address for an internal address.
+// Addresses without a translation are returned unchanged.
+func (translator *addressTranslator) Translate(addr net.IP, port int) (net.IP, int) {
+	for i := 0; i < len(translator.internal); i++ {
+		if translator.internal[i].Equal(addr) {
+			return translator.external[i], port
+		}
+	}
+	return addr, port
+}
+
+// String returns the comma separated internal:external pairs
+func (translator *addressTranslator) String() string {
+	pairs := make([]string, len(translator.internal))
+	for i := 0; i < len(translator.internal); i++ {
+		pairs[i] = addressToString(translator.internal[i]) + ":" + addressToString(translator.external[i])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// addressToString returns the string of an address, with IPv6 addresses in brackets
+func addressToString(ip net.IP) string {
+	if ip.To4() == nil {
+		return "[" + ip.String() + "]"
+	}
+	return ip.String()
+}
+
+// DialContext dials with the wrapped dialer, waiting while limit connections are being dialed
+func (dialer *connectLimitDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer.mutex.Lock()
+	if dialer.semaphore == nil {
+		dialer.semaphore = make(chan struct{}, dialer.limit)
+	}
+	semaphore := dialer.semaphore
+	dialer.mutex.Unlock()
+
+	select {
+	case semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-semaphore }()
+
+	return dialer.dialer.DialContext(ctx, network, address)
+}
+
+// Accept returns true if the host is in one of the data centres and in the white list.
+// An empty data centre list or white list accepts all hosts.
+func (filter *hostFilter) Accept(host *gocql.HostInfo) bool {
+	if len(filter.dataCentres) > 0 {
+		dataCentre := host.DataCenter()
+		found := false
+		for i := 0; i < len(filter.dataCentres); i++ {
+			if filter.dataCentres[i] == dataCentre {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(filter.whiteList) > 0 {
+		connectAddress := host.ConnectAddress()
+		for i := 0; i < len(filter.whiteList); i++ {
+			if filter.whiteList[i].Equal(connectAddress) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -1,8 +1,11 @@
 package cql
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,31 +35,89 @@ func TestClusterConfigToConfigString(t *testing.T) {
 		clusterConfig *gocql.ClusterConfig
 		configString  string
 	}{
-		{info: "empty", clusterConfig: &gocql.ClusterConfig{}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "Consistency", clusterConfig: &gocql.ClusterConfig{Consistency: 1}, configString: "?consistency=one&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "Timeout < 0", clusterConfig: &gocql.ClusterConfig{Timeout: -1}, configString: "?consistency=any&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "Timeout > 0", clusterConfig: &gocql.ClusterConfig{Timeout: 10 * time.Second}, configString: "?consistency=any&timeout=10s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "ConnectTimeout < 0", clusterConfig: &gocql.ClusterConfig{ConnectTimeout: -1}, configString: "?consistency=any&timeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "ConnectTimeout > 0", clusterConfig: &gocql.ClusterConfig{ConnectTimeout: 10 * time.Second}, configString: "?consistency=any&timeout=0s&connectTimeout=10s&writeCoalesceWaitTime=0s"},
-		{info: "Keyspace", clusterConfig: &gocql.ClusterConfig{Keyspace: "system"}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&keyspace=system&writeCoalesceWaitTime=0s"},
-		{info: "NumConns < 2", clusterConfig: &gocql.ClusterConfig{NumConns: 1}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "NumConns > 1", clusterConfig: &gocql.ClusterConfig{NumConns: 2}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&numConns=2&writeCoalesceWaitTime=0s"},
-		{info: "IgnorePeerAddr false DisableInitialHostLookup false", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: false, DisableInitialHostLookup: false}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "IgnorePeerAddr true DisableInitialHostLookup false", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: true, DisableInitialHostLookup: false}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&ignorePeerAddr=true&writeCoalesceWaitTime=0s"},
-		{info: "IgnorePeerAddr false DisableInitialHostLookup true", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: false, DisableInitialHostLookup: true}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&disableInitialHostLookup=true&writeCoalesceWaitTime=0s"},
-		{info: "IgnorePeerAddr true DisableInitialHostLookup true", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: true, DisableInitialHostLookup: true}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&ignorePeerAddr=true&disableInitialHostLookup=true&writeCoalesceWaitTime=0s"},
-		{info: "WriteCoalesceWaitTime 1s", clusterConfig: &gocql.ClusterConfig{WriteCoalesceWaitTime: time.Second}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=1s"},
-		{info: "Authenticator empty", clusterConfig: &gocql.ClusterConfig{Authenticator: gocql.PasswordAuthenticator{}}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "Authenticator username", clusterConfig: &gocql.ClusterConfig{Authenticator: gocql.PasswordAuthenticator{Username: "alice@bob.com"}}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s&username=alice%40bob.com"},
-		{info: "Authenticator username password", clusterConfig: &gocql.ClusterConfig{Authenticator: gocql.PasswordAuthenticator{Username: "alice@bob.com", Password: "top$ecret"}}, configString: "?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s&username=alice%40bob.com&password=top%24ecret"},
-		{info: "Host", clusterConfig: &gocql.ClusterConfig{Hosts: []string{"one"}}, configString: "one?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "Hosts", clusterConfig: &gocql.ClusterConfig{Hosts: []string{"one", "two", "three"}}, configString: "one,two,three?consistency=any&timeout=0s&connectTimeout=0s&writeCoalesceWaitTime=0s"},
-		{info: "SslOptions empty", clusterConfig: cfgWithSsl(&gocql.SslOptions{}), configString: "127.0.0.1?timeout=600ms&connectTimeout=600ms&numConns=2"},
-		{info: "SslOptions caPath", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/some path.pem"}), configString: "127.0.0.1?timeout=600ms&connectTimeout=600ms&numConns=2&caPath=%2Fsome+path.pem"},
-		{info: "SslOptions keyPath", clusterConfig: cfgWithSsl(&gocql.SslOptions{KeyPath: "/some+path.pem"}), configString: "127.0.0.1?timeout=600ms&connectTimeout=600ms&numConns=2&keyPath=%2Fsome%2Bpath.pem"},
-		{info: "SslOptions certPath", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/some path.pem"}), configString: "127.0.0.1?timeout=600ms&connectTimeout=600ms&numConns=2&certPath=%2Fsome+path.pem"},
-		{info: "SslOptions enableHostVerification", clusterConfig: cfgWithSsl(&gocql.SslOptions{EnableHostVerification: true}), configString: "127.0.0.1?timeout=600ms&connectTimeout=600ms&numConns=2&enableHostVerification=true"},
-		{info: "SslOptions caPath keyPath certPath enableHostVerification", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/some path.pem", KeyPath: "/some+path.pem", CertPath: "/some path.pem", EnableHostVerification: true}), configString: "127.0.0.1?timeout=600ms&connectTimeout=600ms&numConns=2&enableHostVerification=true&keyPath=%2Fsome%2Bpath.pem&certPath=%2Fsome+path.pem&caPath=%2Fsome+path.pem"},
+		{info: "empty", clusterConfig: &gocql.ClusterConfig{}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "Consistency", clusterConfig: &gocql.ClusterConfig{Consistency: 1}, configString: "?connectTimeout=0s&consistency=one&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "Timeout < 0", clusterConfig: &gocql.ClusterConfig{Timeout: -1}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&writeCoalesceWaitTime=0s"},
+		{info: "Timeout > 0", clusterConfig: &gocql.ClusterConfig{Timeout: 10 * time.Second}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=10s&writeCoalesceWaitTime=0s"},
+		{info: "ConnectTimeout < 0", clusterConfig: &gocql.ClusterConfig{ConnectTimeout: -1}, configString: "?consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "ConnectTimeout > 0", clusterConfig: &gocql.ClusterConfig{ConnectTimeout: 10 * time.Second}, configString: "?connectTimeout=10s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "Keyspace", clusterConfig: &gocql.ClusterConfig{Keyspace: "system"}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&keyspace=system&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "NumConns 1", clusterConfig: &gocql.ClusterConfig{NumConns: 1}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&numConns=1&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "NumConns default", clusterConfig: &gocql.ClusterConfig{NumConns: 2}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "IgnorePeerAddr false DisableInitialHostLookup false", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: false, DisableInitialHostLookup: false}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "IgnorePeerAddr true DisableInitialHostLookup false", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: true, DisableInitialHostLookup: false}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&ignorePeerAddr=true&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "IgnorePeerAddr false DisableInitialHostLookup true", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: false, DisableInitialHostLookup: true}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&disableInitialHostLookup=true&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "IgnorePeerAddr true DisableInitialHostLookup true", clusterConfig: &gocql.ClusterConfig{IgnorePeerAddr: true, DisableInitialHostLookup: true}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&disableInitialHostLookup=true&ignorePeerAddr=true&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "WriteCoalesceWaitTime 1s", clusterConfig: &gocql.ClusterConfig{WriteCoalesceWaitTime: time.Second}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=1s"},
+		{info: "Port default", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Port = 9042 }), configString: "127.0.0.1"},
+		{info: "Port 9043", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Port = 9043 }), configString: "127.0.0.1?port=9043"},
+		{info: "ProtoVersion 4", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ProtoVersion = 4 }), configString: "127.0.0.1?protoVersion=4"},
+		{info: "CQLVersion default", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.CQLVersion = "3.0.0" }), configString: "127.0.0.1"},
+		{info: "CQLVersion 3.4.0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.CQLVersion = "3.4.0" }), configString: "127.0.0.1?cqlVersion=3.4.0"},
+		{info: "CQLVersion escaped", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.CQLVersion = "3&4=0" }), configString: "127.0.0.1?cqlVersion=3%264%3D0"},
+		{info: "PageSize 100", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PageSize = 100 }), configString: "127.0.0.1?pageSize=100"},
+		{info: "PageSize 0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PageSize = 0 }), configString: "127.0.0.1?pageSize=0"},
+		{info: "SerialConsistency serial", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.Serial }), configString: "127.0.0.1?serialConsistency=serial"},
+		{info: "SerialConsistency localSerial", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.LocalSerial }), configString: "127.0.0.1?serialConsistency=localSerial"},
+		{info: "DefaultTimestamp true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultTimestamp = true }), configString: "127.0.0.1"},
+		{info: "DefaultTimestamp false", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultTimestamp = false }), configString: "127.0.0.1?defaultTimestamp=false"},
+		{info: "ReconnectInterval 10s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ReconnectInterval = 10 * time.Second }), configString: "127.0.0.1?reconnectInterval=10s"},
+		{info: "MaxWaitSchemaAgreement 120s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.MaxWaitSchemaAgreement = 120 * time.Second }), configString: "127.0.0.1?maxWaitSchemaAgreement=2m0s"},
+		{info: "SocketKeepalive 15s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SocketKeepalive = 15 * time.Second }), configString: "127.0.0.1?socketKeepalive=15s"},
+		{info: "connectConcurrency 4", clusterConfig: cfgWithConnectConcurrency(4, nil), configString: "127.0.0.1?connectConcurrency=4"},
+		{info: "MaxRoutingKeyInfo 500", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.MaxRoutingKeyInfo = 500 }), configString: "127.0.0.1?maxRoutingKeyInfo=500"},
+		{info: "WriteTimeout 5s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.WriteTimeout = 5 * time.Second }), configString: "127.0.0.1?writeTimeout=5s"},
+		{info: "Timeout WriteTimeout", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Timeout = time.Second; cfg.WriteTimeout = 5 * time.Second }), configString: "127.0.0.1?timeout=1s&writeTimeout=5s"},
+		{info: "DefaultIdempotence true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultIdempotence = true }), configString: "127.0.0.1?defaultIdempotence=true"},
+		{info: "DisableSkipMetadata true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DisableSkipMetadata = true }), configString: "127.0.0.1?disableSkipMetadata=true"},
+		{info: "Compressor snappy", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Compressor = gocql.SnappyCompressor{} }), configString: "127.0.0.1?compressor=snappy"},
+		{info: "Compressor snappy pointer", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Compressor = &gocql.SnappyCompressor{} }), configString: "127.0.0.1?compressor=snappy"},
+		{info: "RetryPolicy SimpleRetryPolicy", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.RetryPolicy = &gocql.SimpleRetryPolicy{NumRetries: 3} }), configString: "127.0.0.1?numRetries=3"},
+		{info: "ReconnectionPolicy constant default", clusterConfig: cfgWithReconnectionPolicy(&gocql.ConstantReconnectionPolicy{MaxRetries: 3, Interval: time.Second}), configString: "127.0.0.1"},
+		{info: "ReconnectionPolicy constant", clusterConfig: cfgWithReconnectionPolicy(&gocql.ConstantReconnectionPolicy{MaxRetries: 5, Interval: 2 * time.Second}), configString: "127.0.0.1?reconnectInitialInterval=2s&reconnectMaxRetries=5&reconnectPolicy=constant"},
+		{info: "ReconnectionPolicy exponential", clusterConfig: cfgWithReconnectionPolicy(&gocql.ExponentialReconnectionPolicy{MaxRetries: 5, InitialInterval: time.Second, MaxInterval: time.Minute}), configString: "127.0.0.1?reconnectInitialInterval=1s&reconnectMaxInterval=1m0s&reconnectMaxRetries=5&reconnectPolicy=exponential"},
+		{info: "HostSelectionPolicy roundrobin", clusterConfig: cfgWithHostSelectionPolicy("roundrobin", ""), configString: "127.0.0.1?hostSelectionPolicy=roundrobin"},
+		{info: "HostSelectionPolicy tokenaware", clusterConfig: cfgWithHostSelectionPolicy("tokenaware", ""), configString: "127.0.0.1?hostSelectionPolicy=tokenaware"},
+		{info: "HostSelectionPolicy tokenaware,dcaware", clusterConfig: cfgWithHostSelectionPolicy("tokenaware,dcaware", "dc 1"), configString: "127.0.0.1?hostSelectionPolicy=tokenaware,dcaware&localDC=dc+1"},
+		{info: "HostSelectionPolicy tokenaware shuffleReplicas", clusterConfig: cfgWithTokenAware("tokenaware", "", true, false), configString: "127.0.0.1?hostSelectionPolicy=tokenaware&shuffleReplicas=true"},
+		{info: "HostSelectionPolicy tokenaware,dcaware shuffleReplicas", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc1", true, false), configString: "127.0.0.1?hostSelectionPolicy=tokenaware,dcaware&localDC=dc1&shuffleReplicas=true"},
+		{info: "HostSelectionPolicy tokenaware,dcaware allowRemoteDCs", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc1", false, true), configString: "127.0.0.1?allowRemoteDCsForLocalConsistency=true&hostSelectionPolicy=tokenaware,dcaware&localDC=dc1"},
+		{info: "HostSelectionPolicy tokenaware,dcaware shuffleReplicas allowRemoteDCs", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc1", true, true), configString: "127.0.0.1?allowRemoteDCsForLocalConsistency=true&hostSelectionPolicy=tokenaware,dcaware&localDC=dc1&shuffleReplicas=true"},
+		{info: "HostSelectionPolicy gocql", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PoolConfig.HostSelectionPolicy = gocql.RoundRobinHostPolicy() }), configString: "127.0.0.1"},
+		{info: "WriteCoalesceWaitTime 0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.WriteCoalesceWaitTime = 0 }), configString: "127.0.0.1?writeCoalesceWaitTime=0s"},
+		{info: "Events DisableNodeStatusEvents", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableNodeStatusEvents = true }), configString: "127.0.0.1?disableNodeStatusEvents=true"},
+		{info: "Events all", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+			cfg.Events.DisableNodeStatusEvents = true
+			cfg.Events.DisableTopologyEvents = true
+			cfg.Events.DisableSchemaEvents = true
+		}), configString: "127.0.0.1?disableNodeStatusEvents=true&disableSchemaEvents=true&disableTopologyEvents=true"},
+		{info: "HostSelectionPolicy localDC", clusterConfig: cfgWithHostSelectionPolicy("", "us-east-1"), configString: "127.0.0.1?localDC=us-east-1"},
+		{info: "AddressTranslator", clusterConfig: cfgWithAddressTranslator("10.0.0.1:1.2.3.4,[fd00::1]:[2001:db8::1]"), configString: "127.0.0.1?addressTranslator=10.0.0.1:1.2.3.4,[fd00::1]:[2001:db8::1]"},
+		{info: "HostFilter dcFilter", clusterConfig: cfgWithHostFilter([]string{"dc1", "dc 2"}), configString: "127.0.0.1?dcFilter=dc1,dc+2"},
+		{info: "HostFilter whitelist", clusterConfig: cfgWithHostFilter(nil, "10.0.0.1", "2001:db8::1"), configString: "127.0.0.1?hostFilter=whitelist:10.0.0.1,2001:db8::1"},
+		{info: "HostFilter dcFilter whitelist", clusterConfig: cfgWithHostFilter([]string{"dc1"}, "10.0.0.1"), configString: "127.0.0.1?dcFilter=dc1&hostFilter=whitelist:10.0.0.1"},
+		{info: "default", clusterConfig: NewClusterConfig(), configString: "127.0.0.1"},
+		{info: "NumConns default", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.NumConns = 2 }), configString: "127.0.0.1"},
+		{info: "NumConns 3", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.NumConns = 3 }), configString: "127.0.0.1?numConns=3"},
+		{info: "ConnectTimeout 1s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ConnectTimeout = time.Second }), configString: "127.0.0.1?connectTimeout=1s"},
+		{info: "Keyspace escaped", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Keyspace = "my&keyspace=1" }), configString: "127.0.0.1?keyspace=my%26keyspace%3D1"},
+		{info: "Authenticator empty", clusterConfig: &gocql.ClusterConfig{Authenticator: gocql.PasswordAuthenticator{}}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "Authenticator username", clusterConfig: &gocql.ClusterConfig{Authenticator: gocql.PasswordAuthenticator{Username: "alice@bob.com"}}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&username=alice%40bob.com&writeCoalesceWaitTime=0s"},
+		{info: "Authenticator username password", clusterConfig: &gocql.ClusterConfig{Authenticator: gocql.PasswordAuthenticator{Username: "alice@bob.com", Password: "top$ecret"}}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&password=top%24ecret&reconnectInterval=0s&timeout=0s&username=alice%40bob.com&writeCoalesceWaitTime=0s"},
+		{info: "Authenticator dse", clusterConfig: &gocql.ClusterConfig{Authenticator: DsePlainTextAuthenticator{Username: "alice@bob.com", Password: "top$ecret"}}, configString: "?connectTimeout=0s&consistency=any&defaultTimestamp=false&dseAuth=plainText&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&password=top%24ecret&reconnectInterval=0s&timeout=0s&username=alice%40bob.com&writeCoalesceWaitTime=0s"},
+		{info: "Host", clusterConfig: &gocql.ClusterConfig{Hosts: []string{"one"}}, configString: "one?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "Hosts", clusterConfig: &gocql.ClusterConfig{Hosts: []string{"one", "two", "three"}}, configString: "one,two,three?connectTimeout=0s&consistency=any&defaultTimestamp=false&maxRoutingKeyInfo=0&maxWaitSchemaAgreement=0s&pageSize=0&reconnectInterval=0s&timeout=0s&writeCoalesceWaitTime=0s"},
+		{info: "SslOptions empty", clusterConfig: cfgWithSsl(&gocql.SslOptions{}), configString: "127.0.0.1?enableHostVerification=false"},
+		{info: "SslOptions caPath", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/some path.pem"}), configString: "127.0.0.1?caPath=%2Fsome+path.pem&enableHostVerification=false"},
+		{info: "SslOptions keyPath", clusterConfig: cfgWithSsl(&gocql.SslOptions{KeyPath: "/some+path.pem"}), configString: "127.0.0.1?enableHostVerification=false&keyPath=%2Fsome%2Bpath.pem"},
+		{info: "SslOptions certPath", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/some path.pem"}), configString: "127.0.0.1?certPath=%2Fsome+path.pem&enableHostVerification=false"},
+		{info: "SslOptions enableHostVerification", clusterConfig: cfgWithSsl(&gocql.SslOptions{EnableHostVerification: true}), configString: "127.0.0.1?enableHostVerification=true"},
+		{info: "SslOptions caPath keyPath certPath enableHostVerification", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/some path.pem", KeyPath: "/some+path.pem", CertPath: "/some path.pem", EnableHostVerification: true}), configString: "127.0.0.1?caPath=%2Fsome+path.pem&certPath=%2Fsome+path.pem&enableHostVerification=true&keyPath=%2Fsome%2Bpath.pem"},
+		{info: "SslOptions sslInsecureSkipVerify", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{InsecureSkipVerify: true}}), configString: "127.0.0.1?enableHostVerification=false&sslInsecureSkipVerify=true"},
+		{info: "SslOptions sslInsecureSkipVerify false", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{}}), configString: "127.0.0.1?enableHostVerification=false&sslInsecureSkipVerify=false"},
+		{info: "SslOptions certPath keyPath sslInsecureSkipVerify", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/cert/path", KeyPath: "/key/path", Config: &tls.Config{InsecureSkipVerify: true}}), configString: "127.0.0.1?certPath=%2Fcert%2Fpath&enableHostVerification=false&keyPath=%2Fkey%2Fpath&sslInsecureSkipVerify=true"},
+		{info: "SslOptions sslMinVersion", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{MinVersion: tls.VersionTLS12}}), configString: "127.0.0.1?enableHostVerification=false&sslInsecureSkipVerify=false&sslMinVersion=1.2"},
+		{info: "SslOptions sslInsecureSkipVerify sslMinVersion", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS11}}), configString: "127.0.0.1?enableHostVerification=false&sslInsecureSkipVerify=true&sslMinVersion=1.1"},
 	}
 	for _, test := range tests {
 		configString := ClusterConfigToConfigString(test.clusterConfig)
@@ -78,6 +139,59 @@ func cfgWithAuth(auth gocql.PasswordAuthenticator) *gocql.ClusterConfig {
 	return cfg
 }
 
+func cfgWithReconnectionPolicy(policy gocql.ReconnectionPolicy) *gocql.ClusterConfig {
+	cfg := NewClusterConfig()
+	cfg.ReconnectionPolicy = policy
+	return cfg
+}
+
+func cfgWithHostSelectionPolicy(name string, localDC string) *gocql.ClusterConfig {
+	return cfgWithTokenAware(name, localDC, false, false)
+}
+
+func cfgWithTokenAware(name string, localDC string, shuffleReplicas bool, allowRemoteDCs bool) *gocql.ClusterConfig {
+	cfg := NewClusterConfig()
+	policy, err := newHostSelectionPolicy(name, localDC, shuffleReplicas, allowRemoteDCs)
+	if err != nil {
+		panic(err)
+	}
+	cfg.PoolConfig.HostSelectionPolicy = policy
+	return cfg
+}
+
+func cfgWithConnectConcurrency(limit int, f func(cfg *gocql.ClusterConfig)) *gocql.ClusterConfig {
+	cfg := NewClusterConfig()
+	if f != nil {
+		f(cfg)
+	}
+	dialer := &net.Dialer{Timeout: cfg.ConnectTimeout}
+	if cfg.SocketKeepalive > 0 {
+		dialer.KeepAlive = cfg.SocketKeepalive
+	}
+	cfg.Dialer = &connectLimitDialer{dialer: dialer, limit: limit}
+	return cfg
+}
+
+func cfgWithAddressTranslator(pairs string) *gocql.ClusterConfig {
+	cfg := NewClusterConfig()
+	translator, err := newAddressTranslator(pairs)
+	if err != nil {
+		panic(err)
+	}
+	cfg.AddressTranslator = translator
+	return cfg
+}
+
+func cfgWithHostFilter(dataCentres []string, whiteList ...string) *gocql.ClusterConfig {
+	cfg := NewClusterConfig()
+	filter := &hostFilter{dataCentres: dataCentres}
+	for i := 0; i < len(whiteList); i++ {
+		filter.whiteList = append(filter.whiteList, net.ParseIP(whiteList[i]))
+	}
+	cfg.HostFilter = filter
+	return cfg
+}
+
 func cfgWithSsl(sslCfg *gocql.SslOptions) *gocql.ClusterConfig {
 	cfg := NewClusterConfig()
 	cfg.SslOpts = sslCfg
@@ -86,6 +200,11 @@ func cfgWithSsl(sslCfg *gocql.SslOptions) *gocql.ClusterConfig {
 
 func TestConfigStringToClusterConfig(t *testing.T) {
 	tests := []TestStringToConfigStruct{
+		// Missing `?`
+		{info: "missing '?' &", configString: "127.0.0.1&extra", err: fmt.Errorf("invalid hosts, missing ?: 127.0.0.1&extra")},
+		{info: "missing '?' =", configString: "127.0.0.1&consistency=one", err: fmt.Errorf("invalid hosts, missing ?: 127.0.0.1&consistency=one")},
+		{info: "missing '?' no hosts", configString: "consistency=one", err: fmt.Errorf("invalid hosts, missing ?: consistency=one")},
+
 		// Missing `=`
 		{info: "missing '=' consistency", configString: "?consistency", err: fmt.Errorf("missing =")},
 		{info: "missing '=' keyspace", configString: "?keyspace", err: fmt.Errorf("missing =")},
@@ -95,6 +214,32 @@ func TestConfigStringToClusterConfig(t *testing.T) {
 		{info: "missing '=' ignorePeerAddr", configString: "?ignorePeerAddr", err: fmt.Errorf("missing =")},
 		{info: "missing '=' disableInitialHostLookup", configString: "?disableInitialHostLookup", err: fmt.Errorf("missing =")},
 		{info: "missing '=' writeCoalesceWaitTime", configString: "?writeCoalesceWaitTime", err: fmt.Errorf("missing =")},
+		{info: "missing '=' port", configString: "?port", err: fmt.Errorf("missing =")},
+		{info: "missing '=' protoVersion", configString: "?protoVersion", err: fmt.Errorf("missing =")},
+		{info: "missing '=' cqlVersion", configString: "?cqlVersion", err: fmt.Errorf("missing =")},
+		{info: "missing '=' pageSize", configString: "?pageSize", err: fmt.Errorf("missing =")},
+		{info: "missing '=' serialConsistency", configString: "?serialConsistency", err: fmt.Errorf("missing =")},
+		{info: "missing '=' defaultTimestamp", configString: "?defaultTimestamp", err: fmt.Errorf("missing =")},
+		{info: "missing '=' reconnectInterval", configString: "?reconnectInterval", err: fmt.Errorf("missing =")},
+		{info: "missing '=' maxWaitSchemaAgreement", configString: "?maxWaitSchemaAgreement", err: fmt.Errorf("missing =")},
+		{info: "missing '=' socketKeepalive", configString: "?socketKeepalive", err: fmt.Errorf("missing =")},
+		{info: "missing '=' maxRoutingKeyInfo", configString: "?maxRoutingKeyInfo", err: fmt.Errorf("missing =")},
+		{info: "missing '=' writeTimeout", configString: "?writeTimeout", err: fmt.Errorf("missing =")},
+		{info: "missing '=' defaultIdempotence", configString: "?defaultIdempotence", err: fmt.Errorf("missing =")},
+		{info: "missing '=' disableSkipMetadata", configString: "?disableSkipMetadata", err: fmt.Errorf("missing =")},
+		{info: "missing '=' compressor", configString: "?compressor", err: fmt.Errorf("missing =")},
+		{info: "missing '=' numRetries", configString: "?numRetries", err: fmt.Errorf("missing =")},
+		{info: "missing '=' reconnectPolicy", configString: "?reconnectPolicy", err: fmt.Errorf("missing =")},
+		{info: "missing '=' reconnectMaxRetries", configString: "?reconnectMaxRetries", err: fmt.Errorf("missing =")},
+		{info: "missing '=' reconnectInitialInterval", configString: "?reconnectInitialInterval", err: fmt.Errorf("missing =")},
+		{info: "missing '=' reconnectMaxInterval", configString: "?reconnectMaxInterval", err: fmt.Errorf("missing =")},
+		{info: "missing '=' hostSelectionPolicy", configString: "?hostSelectionPolicy", err: fmt.Errorf("missing =")},
+		{info: "missing '=' localDC", configString: "?localDC", err: fmt.Errorf("missing =")},
+		{info: "missing '=' sslInsecureSkipVerify", configString: "?sslInsecureSkipVerify", err: fmt.Errorf("missing =")},
+		{info: "missing '=' sslMinVersion", configString: "?sslMinVersion", err: fmt.Errorf("missing =")},
+		{info: "missing '=' addressTranslator", configString: "?addressTranslator", err: fmt.Errorf("missing =")},
+		{info: "missing '=' dcFilter", configString: "?dcFilter", err: fmt.Errorf("missing =")},
+		{info: "missing '=' hostFilter", configString: "?hostFilter", err: fmt.Errorf("missing =")},
 		{info: "missing '=' username", configString: "?username", err: fmt.Errorf("missing =")},
 		{info: "missing '=' password", configString: "?password", err: fmt.Errorf("missing =")},
 		{info: "missing '=' enableHostVerification", configString: "?enableHostVerification", err: fmt.Errorf("missing =")},
@@ -104,6 +249,15 @@ func TestConfigStringToClusterConfig(t *testing.T) {
 
 		// Missing value
 		{info: "empty consistency", configString: "?consistency=", err: fmt.Errorf("failed for: consistency = ")},
+		{info: "failed ParseBool noKeyspace", configString: "?noKeyspace=foobar", err: fmt.Errorf("failed for: noKeyspace = foobar")},
+		{info: "appName not supported", configString: "?appName=dashboard", err: fmt.Errorf("failed for: appName = dashboard, application startup options are not supported by gocql")},
+		{info: "appVersion not supported", configString: "?appVersion=1.0.0", err: fmt.Errorf("failed for: appVersion = 1.0.0, application startup options are not supported by gocql")},
+		{info: "noKeyspace keyspace", configString: "?noKeyspace=true&keyspace=system", err: fmt.Errorf("noKeyspace=true can not be used with keyspace = system")},
+		{info: "keyspace noKeyspace", configString: "?keyspace=system&noKeyspace=1", err: fmt.Errorf("noKeyspace=true can not be used with keyspace = system")},
+		{info: "invalid consistency", configString: "?consistency=foo", err: fmt.Errorf("failed for: consistency = foo")},
+		{info: "serial consistency", configString: "?consistency=serial", err: fmt.Errorf("failed for: consistency = serial, serial is a serial consistency, use serialConsistency=serial")},
+		{info: "localSerial consistency", configString: "?consistency=localSerial", err: fmt.Errorf("failed for: consistency = localSerial, localSerial is a serial consistency, use serialConsistency=localSerial")},
+		{info: "local_serial consistency", configString: "?consistency=local_serial", err: fmt.Errorf("failed for: consistency = local_serial, local_serial is a serial consistency, use serialConsistency=local_serial")},
 		{info: "empty keyspace", configString: "?keyspace=", err: fmt.Errorf("failed for: keyspace = ")},
 		{info: "empty timeout", configString: "?timeout=", err: fmt.Errorf("failed for: timeout = ")},
 		{info: "empty connectTimeout", configString: "?connectTimeout=", err: fmt.Errorf("failed for: connectTimeout = ")},
@@ -111,6 +265,32 @@ func TestConfigStringToClusterConfig(t *testing.T) {
 		{info: "empty ignorePeerAddr", configString: "?ignorePeerAddr=", err: fmt.Errorf("failed for: ignorePeerAddr = ")},
 		{info: "empty disableInitialHostLookup", configString: "?disableInitialHostLookup=", err: fmt.Errorf("failed for: disableInitialHostLookup = ")},
 		{info: "empty writeCoalesceWaitTime", configString: "?writeCoalesceWaitTime=", err: fmt.Errorf("failed for: writeCoalesceWaitTime = ")},
+		{info: "empty port", configString: "?port=", err: fmt.Errorf("failed for: port = ")},
+		{info: "empty protoVersion", configString: "?protoVersion=", err: fmt.Errorf("failed for: protoVersion = ")},
+		{info: "empty cqlVersion", configString: "?cqlVersion=", err: fmt.Errorf("failed for: cqlVersion = ")},
+		{info: "empty pageSize", configString: "?pageSize=", err: fmt.Errorf("failed for: pageSize = ")},
+		{info: "empty serialConsistency", configString: "?serialConsistency=", err: fmt.Errorf("failed for: serialConsistency = ")},
+		{info: "empty defaultTimestamp", configString: "?defaultTimestamp=", err: fmt.Errorf("failed for: defaultTimestamp = ")},
+		{info: "empty reconnectInterval", configString: "?reconnectInterval=", err: fmt.Errorf("failed for: reconnectInterval = ")},
+		{info: "empty maxWaitSchemaAgreement", configString: "?maxWaitSchemaAgreement=", err: fmt.Errorf("failed for: maxWaitSchemaAgreement = ")},
+		{info: "empty socketKeepalive", configString: "?socketKeepalive=", err: fmt.Errorf("failed for: socketKeepalive = ")},
+		{info: "empty maxRoutingKeyInfo", configString: "?maxRoutingKeyInfo=", err: fmt.Errorf("failed for: maxRoutingKeyInfo = ")},
+		{info: "empty writeTimeout", configString: "?writeTimeout=", err: fmt.Errorf("failed for: writeTimeout = ")},
+		{info: "empty defaultIdempotence", configString: "?defaultIdempotence=", err: fmt.Errorf("failed for: defaultIdempotence = ")},
+		{info: "empty disableSkipMetadata", configString: "?disableSkipMetadata=", err: fmt.Errorf("failed for: disableSkipMetadata = ")},
+		{info: "empty compressor", configString: "?compressor=", err: fmt.Errorf("failed for: compressor = ")},
+		{info: "empty numRetries", configString: "?numRetries=", err: fmt.Errorf("failed for: numRetries = ")},
+		{info: "empty reconnectPolicy", configString: "?reconnectPolicy=", err: fmt.Errorf("failed for: reconnectPolicy = ")},
+		{info: "empty reconnectMaxRetries", configString: "?reconnectMaxRetries=", err: fmt.Errorf("failed for: reconnectMaxRetries = ")},
+		{info: "empty reconnectInitialInterval", configString: "?reconnectInitialInterval=", err: fmt.Errorf("failed for: reconnectInitialInterval = ")},
+		{info: "empty reconnectMaxInterval", configString: "?reconnectMaxInterval=", err: fmt.Errorf("failed for: reconnectMaxInterval = ")},
+		{info: "empty hostSelectionPolicy", configString: "?hostSelectionPolicy=", err: fmt.Errorf("failed for: hostSelectionPolicy = ")},
+		{info: "empty localDC", configString: "?localDC=", err: fmt.Errorf("failed for: localDC = ")},
+		{info: "empty sslInsecureSkipVerify", configString: "?sslInsecureSkipVerify=", err: fmt.Errorf("failed for: sslInsecureSkipVerify = ")},
+		{info: "empty sslMinVersion", configString: "?sslMinVersion=", err: fmt.Errorf("failed for: sslMinVersion = ")},
+		{info: "empty addressTranslator", configString: "?addressTranslator=", err: fmt.Errorf("failed for: addressTranslator = ")},
+		{info: "empty dcFilter", configString: "?dcFilter=", err: fmt.Errorf("failed for: dcFilter = ")},
+		{info: "empty hostFilter", configString: "?hostFilter=", err: fmt.Errorf("failed for: hostFilter = ")},
 		{info: "empty ok username", configString: "?username=", clusterConfig: cfgWithAuth(gocql.PasswordAuthenticator{})},
 		{info: "empty ok password", configString: "?password=", clusterConfig: cfgWithAuth(gocql.PasswordAuthenticator{})},
 		{info: "empty enableHostVerification", configString: "?enableHostVerification=", err: fmt.Errorf("failed for: enableHostVerification = ")},
@@ -118,37 +298,173 @@ func TestConfigStringToClusterConfig(t *testing.T) {
 		{info: "empty ok certPath", configString: "?certPath=", clusterConfig: cfgWithSsl(&gocql.SslOptions{})},
 		{info: "empty ok keyPath", configString: "?keyPath=", clusterConfig: cfgWithSsl(&gocql.SslOptions{})},
 
+		// Duplicate key
+		{info: "duplicate key consistency", configString: "?consistency=quorum&consistency=one", err: fmt.Errorf("duplicate key: consistency")},
+		{info: "duplicate key trimmed", configString: "?keyspace=one& keyspace=two", err: fmt.Errorf("duplicate key: keyspace")},
+
+		// Invalid value
+		{info: "invalid serialConsistency", configString: "?serialConsistency=quorum", err: fmt.Errorf("failed for: serialConsistency = quorum")},
+		{info: "invalid compressor", configString: "?compressor=lz4", err: fmt.Errorf("failed for: compressor = lz4")},
+		{info: "invalid reconnectPolicy", configString: "?reconnectPolicy=random", err: fmt.Errorf("failed for: reconnectPolicy = random")},
+		{info: "reconnectMaxInterval without exponential", configString: "?reconnectMaxInterval=1m", err: fmt.Errorf("reconnectMaxInterval requires reconnectPolicy=exponential")},
+		{info: "invalid hostSelectionPolicy", configString: "?hostSelectionPolicy=random", err: fmt.Errorf("failed for: hostSelectionPolicy = random")},
+		{info: "hostSelectionPolicy dcaware missing localDC", configString: "?hostSelectionPolicy=tokenaware,dcaware", err: fmt.Errorf("localDC required for hostSelectionPolicy: tokenaware,dcaware")},
+		{info: "localDC hostSelectionPolicy tokenaware", configString: "?hostSelectionPolicy=tokenaware&localDC=dc1", err: fmt.Errorf("localDC not supported for hostSelectionPolicy: tokenaware")},
+		{info: "shuffleReplicas hostSelectionPolicy roundrobin", configString: "?hostSelectionPolicy=roundrobin&shuffleReplicas=true", err: fmt.Errorf("shuffleReplicas not supported for hostSelectionPolicy: roundrobin")},
+		{info: "shuffleReplicas without hostSelectionPolicy", configString: "?shuffleReplicas=true", err: fmt.Errorf("shuffleReplicas not supported for hostSelectionPolicy: ")},
+		{info: "failed ParseBool shuffleReplicas", configString: "?shuffleReplicas=foobar", err: fmt.Errorf("failed for: shuffleReplicas = foobar")},
+		{info: "allowRemoteDCsForLocalConsistency hostSelectionPolicy tokenaware", configString: "?hostSelectionPolicy=tokenaware&allowRemoteDCsForLocalConsistency=true", err: fmt.Errorf("allowRemoteDCsForLocalConsistency not supported for hostSelectionPolicy: tokenaware")},
+		{info: "allowRemoteDCsForLocalConsistency localDC", configString: "?localDC=dc1&allowRemoteDCsForLocalConsistency=true", err: fmt.Errorf("allowRemoteDCsForLocalConsistency not supported for hostSelectionPolicy: ")},
+		{info: "failed ParseBool allowRemoteDCsForLocalConsistency", configString: "?allowRemoteDCsForLocalConsistency=foobar", err: fmt.Errorf("failed for: allowRemoteDCsForLocalConsistency = foobar")},
+		{info: "invalid sslMinVersion", configString: "?sslMinVersion=3.0", err: fmt.Errorf("failed for: sslMinVersion = 3.0")},
+		{info: "invalid dseAuth", configString: "?dseAuth=kerberos", err: fmt.Errorf("failed for: dseAuth = kerberos")},
+		{info: "empty dseAuth", configString: "?dseAuth=", err: fmt.Errorf("failed for: dseAuth = ")},
+		{info: "dseAuth gssapi", configString: "?dseAuth=gssapi", err: fmt.Errorf("dseAuth gssapi is not supported, Kerberos libraries are not available")},
+		{info: "invalid addressTranslator missing external", configString: "?addressTranslator=10.0.0.1", err: fmt.Errorf("failed for: addressTranslator = 10.0.0.1")},
+		{info: "invalid addressTranslator address", configString: "?addressTranslator=10.0.0.1:foobar", err: fmt.Errorf("failed for: addressTranslator = 10.0.0.1:foobar")},
+		{info: "invalid addressTranslator IPv6", configString: "?addressTranslator=[fd00::1:1.2.3.4", err: fmt.Errorf("failed for: addressTranslator = [fd00::1:1.2.3.4")},
+		{info: "invalid dcFilter empty data centre", configString: "?dcFilter=dc1,,dc2", err: fmt.Errorf("failed for: dcFilter = dc1,,dc2")},
+		{info: "invalid hostFilter type", configString: "?hostFilter=blacklist:10.0.0.1", err: fmt.Errorf("failed for: hostFilter = blacklist:10.0.0.1")},
+		{info: "invalid hostFilter missing :", configString: "?hostFilter=whitelist", err: fmt.Errorf("failed for: hostFilter = whitelist")},
+		{info: "invalid hostFilter address", configString: "?hostFilter=whitelist:10.0.0.1,foobar", err: fmt.Errorf("failed for: hostFilter = whitelist:10.0.0.1,foobar")},
+		{info: "hosts different ports", configString: "10.0.0.1:9043,10.0.0.2:9044", err: fmt.Errorf("hosts have different ports: 9043 and 9044")},
+		{info: "hosts invalid port", configString: "10.0.0.1:foobar", err: fmt.Errorf("invalid port for host: 10.0.0.1:foobar")},
+		{info: "hosts missing port", configString: "10.0.0.1:", err: fmt.Errorf("invalid port for host: 10.0.0.1:")},
+		{info: "hosts port does not match port", configString: "10.0.0.1:9043?port=9044", err: fmt.Errorf("port 9044 does not match hosts port 9043")},
+
 		// QueryUnescape
 		{info: "failed QueryUnescape username", configString: "?username=%GG", err: fmt.Errorf("failed for: username = %%GG")},
 		{info: "failed QueryUnescape password", configString: "?password=%GG", err: fmt.Errorf("failed for: password = %%GG")},
+		{info: "failed QueryUnescape cqlVersion", configString: "?cqlVersion=%GG", err: fmt.Errorf("failed for: cqlVersion = %%GG")},
 		{info: "failed QueryUnescape caPath", configString: "?caPath=%GG", err: fmt.Errorf("failed for: caPath = %%GG")},
 		{info: "failed QueryUnescape certPath", configString: "?certPath=%GG", err: fmt.Errorf("failed for: certPath = %%GG")},
 		{info: "failed QueryUnescape keyPath", configString: "?keyPath=%GG", err: fmt.Errorf("failed for: keyPath = %%GG")},
+		{info: "failed QueryUnescape localDC", configString: "?localDC=%GG", err: fmt.Errorf("failed for: localDC = %%GG")},
+		{info: "failed QueryUnescape dcFilter", configString: "?dcFilter=%GG", err: fmt.Errorf("failed for: dcFilter = %%GG")},
+		{info: "failed QueryUnescape keyspace", configString: "?keyspace=%GG", err: fmt.Errorf("failed for: keyspace = %%GG")},
+
+		// ParseInt
+		{info: "failed ParseInt port", configString: "?port=foobar", err: fmt.Errorf("failed for: port = foobar")},
+		{info: "failed port < 1", configString: "?port=0", err: fmt.Errorf("failed for: port = 0")},
+		{info: "failed port > 65535", configString: "?port=65536", err: fmt.Errorf("failed for: port = 65536")},
+		{info: "failed Atoi protoVersion", configString: "?protoVersion=foobar", err: fmt.Errorf("failed for: protoVersion = foobar")},
+		{info: "failed protoVersion < 1", configString: "?protoVersion=0", err: fmt.Errorf("failed for: protoVersion = 0")},
+		{info: "failed protoVersion > 5", configString: "?protoVersion=6", err: fmt.Errorf("failed for: protoVersion = 6")},
+		{info: "failed Atoi pageSize", configString: "?pageSize=foobar", err: fmt.Errorf("failed for: pageSize = foobar")},
+		{info: "failed pageSize < 0", configString: "?pageSize=-1", err: fmt.Errorf("failed for: pageSize = -1")},
+		{info: "failed Atoi maxRoutingKeyInfo", configString: "?maxRoutingKeyInfo=foobar", err: fmt.Errorf("failed for: maxRoutingKeyInfo = foobar")},
+		{info: "failed maxRoutingKeyInfo < 0", configString: "?maxRoutingKeyInfo=-1", err: fmt.Errorf("failed for: maxRoutingKeyInfo = -1")},
+		{info: "failed Atoi numRetries", configString: "?numRetries=foobar", err: fmt.Errorf("failed for: numRetries = foobar")},
+		{info: "failed numRetries < 0", configString: "?numRetries=-1", err: fmt.Errorf("failed for: numRetries = -1")},
+		{info: "failed Atoi reconnectMaxRetries", configString: "?reconnectMaxRetries=foobar", err: fmt.Errorf("failed for: reconnectMaxRetries = foobar")},
+		{info: "failed reconnectMaxRetries < 0", configString: "?reconnectMaxRetries=-1", err: fmt.Errorf("failed for: reconnectMaxRetries = -1")},
 
 		// ParseBool
 		{info: "failed ParseBool ignorePeerAddr", configString: "?ignorePeerAddr=foobar", err: fmt.Errorf("failed for: ignorePeerAddr = foobar")},
 		{info: "failed ParseBool disableInitialHostLookup", configString: "?disableInitialHostLookup=foobar", err: fmt.Errorf("failed for: disableInitialHostLookup = foobar")},
+		{info: "failed ParseBool disableNodeStatusEvents", configString: "?disableNodeStatusEvents=foobar", err: fmt.Errorf("failed for: disableNodeStatusEvents = foobar")},
+		{info: "failed ParseBool disableTopologyEvents", configString: "?disableTopologyEvents=foobar", err: fmt.Errorf("failed for: disableTopologyEvents = foobar")},
+		{info: "failed ParseBool disableSchemaEvents", configString: "?disableSchemaEvents=foobar", err: fmt.Errorf("failed for: disableSchemaEvents = foobar")},
 		{info: "failed ParseBool enableHostVerification", configString: "?enableHostVerification=foobar", err: fmt.Errorf("failed for: enableHostVerification = foobar")},
+		{info: "failed ParseBool defaultTimestamp", configString: "?defaultTimestamp=foobar", err: fmt.Errorf("failed for: defaultTimestamp = foobar")},
+		{info: "failed ParseBool defaultIdempotence", configString: "?defaultIdempotence=foobar", err: fmt.Errorf("failed for: defaultIdempotence = foobar")},
+		{info: "failed ParseBool disableSkipMetadata", configString: "?disableSkipMetadata=foobar", err: fmt.Errorf("failed for: disableSkipMetadata = foobar")},
+		{info: "failed ParseBool sslInsecureSkipVerify", configString: "?sslInsecureSkipVerify=foobar", err: fmt.Errorf("failed for: sslInsecureSkipVerify = foobar")},
 
 		// ParseDuration
 		{info: "failed ParseDuration timeout", configString: "?timeout=42", err: fmt.Errorf("failed for: timeout = 42")},
 		{info: "failed ParseDuration connectTimeout", configString: "?connectTimeout=42", err: fmt.Errorf("failed for: connectTimeout = 42")},
 		{info: "failed ParseDuration writeCoalesceWaitTime", configString: "?writeCoalesceWaitTime=42", err: fmt.Errorf("failed for: writeCoalesceWaitTime = 42")},
+		{info: "failed ParseDuration reconnectInterval", configString: "?reconnectInterval=42", err: fmt.Errorf("failed for: reconnectInterval = 42")},
+		{info: "failed ParseDuration maxWaitSchemaAgreement", configString: "?maxWaitSchemaAgreement=42", err: fmt.Errorf("failed for: maxWaitSchemaAgreement = 42")},
+		{info: "failed maxWaitSchemaAgreement < 0", configString: "?maxWaitSchemaAgreement=-1s", err: fmt.Errorf("failed for: maxWaitSchemaAgreement = -1s")},
+		{info: "failed ParseDuration socketKeepalive", configString: "?socketKeepalive=42", err: fmt.Errorf("failed for: socketKeepalive = 42")},
+		{info: "failed ParseInt connectConcurrency", configString: "?connectConcurrency=foobar", err: fmt.Errorf("failed for: connectConcurrency = foobar")},
+		{info: "connectConcurrency 0", configString: "?connectConcurrency=0", err: fmt.Errorf("failed for: connectConcurrency = 0")},
+		{info: "failed ParseDuration writeTimeout", configString: "?writeTimeout=42", err: fmt.Errorf("failed for: writeTimeout = 42")},
+		{info: "failed ParseDuration reconnectInitialInterval", configString: "?reconnectInitialInterval=42", err: fmt.Errorf("failed for: reconnectInitialInterval = 42")},
+		{info: "failed ParseDuration reconnectMaxInterval", configString: "?reconnectMaxInterval=42", err: fmt.Errorf("failed for: reconnectMaxInterval = 42")},
 
 		// Non errors
 		{info: "empty", configString: "", clusterConfig: NewClusterConfig()},
 		{info: "Consistency any", configString: "?consistency=any", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = 0 })},
 		{info: "Consistency one", configString: "?consistency=one", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = 1 })},
+		{info: "Consistency localQuorum", configString: "?consistency=localQuorum", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = gocql.LocalQuorum })},
+		{info: "Consistency eachQuorum", configString: "?consistency=eachQuorum", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = gocql.EachQuorum })},
+		{info: "Consistency localOne", configString: "?consistency=localOne", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = gocql.LocalOne })},
 		{info: "Timeout < 0", configString: "?timeout=-1s", clusterConfig: NewClusterConfig()},
 		{info: "Timeout > 0", configString: "?timeout=1s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Timeout = time.Second })},
 		{info: "ConnectTimeout < 0", configString: "?connectTimeout=-1s", clusterConfig: NewClusterConfig()},
 		{info: "ConnectTimeout > 0", configString: "?connectTimeout=1s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ConnectTimeout = time.Second })},
 		{info: "Keyspace", configString: "?keyspace=system", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Keyspace = "system" })},
+		{info: "NoKeyspace true", configString: "?noKeyspace=true", clusterConfig: NewClusterConfig()},
+		{info: "NoKeyspace false Keyspace", configString: "?noKeyspace=false&keyspace=system", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Keyspace = "system" })},
 		{info: "NumConns < 1", configString: "?numConns=0", clusterConfig: NewClusterConfig()},
 		{info: "NumConns > 1", configString: "?numConns=2", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.NumConns = 2 })},
 		{info: "IgnorePeerAddr true", configString: "?ignorePeerAddr=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.IgnorePeerAddr = true })},
 		{info: "DisableInitialHostLookup true", configString: "?disableInitialHostLookup=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DisableInitialHostLookup = true })},
+		{info: "DisableNodeStatusEvents true", configString: "?disableNodeStatusEvents=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableNodeStatusEvents = true })},
+		{info: "DisableTopologyEvents true", configString: "?disableTopologyEvents=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableTopologyEvents = true })},
+		{info: "DisableSchemaEvents true", configString: "?disableSchemaEvents=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableSchemaEvents = true })},
 		{info: "WriteCoalesceWaitTime 1s", configString: "?writeCoalesceWaitTime=1s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.WriteCoalesceWaitTime = time.Second })},
+		{info: "WriteCoalesceWaitTime 0s", configString: "?writeCoalesceWaitTime=0s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.WriteCoalesceWaitTime = 0 })},
+		{info: "Port", configString: "?port=9043", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Port = 9043 })},
+		{info: "ProtoVersion", configString: "?protoVersion=4", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ProtoVersion = 4 })},
+		{info: "CQLVersion", configString: "?cqlVersion= 3.4.0 ", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.CQLVersion = "3.4.0" })},
+		{info: "CQLVersion escaped", configString: "?cqlVersion=3%264%3D0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.CQLVersion = "3&4=0" })},
+		{info: "PageSize 100", configString: "?pageSize=100", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PageSize = 100 })},
+		{info: "PageSize 0", configString: "?pageSize=0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PageSize = 0 })},
+		{info: "SerialConsistency serial", configString: "?serialConsistency=serial", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.Serial })},
+		{info: "SerialConsistency localSerial", configString: "?serialConsistency=localSerial", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.LocalSerial })},
+		{info: "SerialConsistency local_serial", configString: "?serialConsistency=local_serial", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.LocalSerial })},
+		{info: "DefaultTimestamp true", configString: "?defaultTimestamp=true", clusterConfig: NewClusterConfig()},
+		{info: "DefaultTimestamp false", configString: "?defaultTimestamp=false", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultTimestamp = false })},
+		{info: "ReconnectInterval < 0", configString: "?reconnectInterval=-1s", clusterConfig: NewClusterConfig()},
+		{info: "ReconnectInterval 10s", configString: "?reconnectInterval=10s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ReconnectInterval = 10 * time.Second })},
+		{info: "MaxWaitSchemaAgreement 120s", configString: "?maxWaitSchemaAgreement=120s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.MaxWaitSchemaAgreement = 120 * time.Second })},
+		{info: "SocketKeepalive < 0", configString: "?socketKeepalive=-1s", clusterConfig: NewClusterConfig()},
+		{info: "SocketKeepalive 15s", configString: "?socketKeepalive=15s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SocketKeepalive = 15 * time.Second })},
+		{info: "connectConcurrency 4", configString: "?connectConcurrency=4", clusterConfig: cfgWithConnectConcurrency(4, nil)},
+		{info: "connectConcurrency connectTimeout socketKeepalive", configString: "?connectConcurrency=1&connectTimeout=3s&socketKeepalive=15s",
+			clusterConfig: cfgWithConnectConcurrency(1, func(cfg *gocql.ClusterConfig) {
+				cfg.ConnectTimeout = 3 * time.Second
+				cfg.SocketKeepalive = 15 * time.Second
+			})},
+		{info: "MaxRoutingKeyInfo 500", configString: "?maxRoutingKeyInfo=500", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.MaxRoutingKeyInfo = 500 })},
+		{info: "WriteTimeout < 0", configString: "?writeTimeout=-1s", clusterConfig: NewClusterConfig()},
+		{info: "WriteTimeout > 0", configString: "?writeTimeout=5s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.WriteTimeout = 5 * time.Second })},
+		{info: "Timeout WriteTimeout", configString: "?timeout=1s&writeTimeout=5s", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Timeout = time.Second; cfg.WriteTimeout = 5 * time.Second })},
+		{info: "DefaultIdempotence true", configString: "?defaultIdempotence=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultIdempotence = true })},
+		{info: "DefaultIdempotence false", configString: "?defaultIdempotence=false", clusterConfig: NewClusterConfig()},
+		{info: "DisableSkipMetadata true", configString: "?disableSkipMetadata=true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DisableSkipMetadata = true })},
+		{info: "Compressor snappy", configString: "?compressor=snappy", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Compressor = gocql.SnappyCompressor{} })},
+		{info: "NumRetries", configString: "?numRetries=3", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.RetryPolicy = &gocql.SimpleRetryPolicy{NumRetries: 3} })},
+		{info: "ReconnectionPolicy constant", configString: "?reconnectPolicy=constant&reconnectMaxRetries=5&reconnectInitialInterval=2s", clusterConfig: cfgWithReconnectionPolicy(&gocql.ConstantReconnectionPolicy{MaxRetries: 5, Interval: 2 * time.Second})},
+		{info: "ReconnectionPolicy constant defaults", configString: "?reconnectMaxRetries=5", clusterConfig: cfgWithReconnectionPolicy(&gocql.ConstantReconnectionPolicy{MaxRetries: 5, Interval: time.Second})},
+		{info: "ReconnectionPolicy exponential", configString: "?reconnectMaxInterval=1m&reconnectPolicy=exponential&reconnectMaxRetries=5", clusterConfig: cfgWithReconnectionPolicy(&gocql.ExponentialReconnectionPolicy{MaxRetries: 5, InitialInterval: time.Second, MaxInterval: time.Minute})},
+		{info: "HostSelectionPolicy roundrobin", configString: "?hostSelectionPolicy=roundrobin", clusterConfig: cfgWithHostSelectionPolicy("roundrobin", "")},
+		{info: "HostSelectionPolicy tokenaware", configString: "?hostSelectionPolicy=tokenaware", clusterConfig: cfgWithHostSelectionPolicy("tokenaware", "")},
+		{info: "HostSelectionPolicy tokenaware shuffleReplicas", configString: "?shuffleReplicas=true&hostSelectionPolicy=tokenaware", clusterConfig: cfgWithTokenAware("tokenaware", "", true, false)},
+		{info: "HostSelectionPolicy tokenaware shuffleReplicas false", configString: "?hostSelectionPolicy=tokenaware&shuffleReplicas=false", clusterConfig: cfgWithHostSelectionPolicy("tokenaware", "")},
+		{info: "HostSelectionPolicy tokenaware,dcaware allowRemoteDCs", configString: "?hostSelectionPolicy=tokenaware,dcaware&localDC=dc1&allowRemoteDCsForLocalConsistency=true", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc1", false, true)},
+		{info: "HostSelectionPolicy tokenaware,dcaware allowRemoteDCs false", configString: "?hostSelectionPolicy=tokenaware,dcaware&localDC=dc1&allowRemoteDCsForLocalConsistency=false", clusterConfig: cfgWithHostSelectionPolicy("tokenaware,dcaware", "dc1")},
+		{info: "HostSelectionPolicy tokenaware,dcaware", configString: "?localDC=dc1&hostSelectionPolicy=tokenaware,dcaware", clusterConfig: cfgWithHostSelectionPolicy("tokenaware,dcaware", "dc1")},
+		{info: "HostSelectionPolicy localDC", configString: "?localDC=us-east-1", clusterConfig: cfgWithHostSelectionPolicy("", "us-east-1")},
+		{info: "AddressTranslator", configString: "?addressTranslator=10.0.0.1:1.2.3.4, 10.0.0.2:1.2.3.5", clusterConfig: cfgWithAddressTranslator("10.0.0.1:1.2.3.4,10.0.0.2:1.2.3.5")},
+		{info: "HostFilter dcFilter", configString: "?dcFilter=dc1, dc%202", clusterConfig: cfgWithHostFilter([]string{"dc1", "dc 2"})},
+		{info: "HostFilter whitelist", configString: "?hostFilter=whitelist:10.0.0.1, 10.0.0.2", clusterConfig: cfgWithHostFilter(nil, "10.0.0.1", "10.0.0.2")},
+		{info: "HostFilter whitelist dcFilter", configString: "?hostFilter=whitelist:10.0.0.1&dcFilter=dc1", clusterConfig: cfgWithHostFilter([]string{"dc1"}, "10.0.0.1")},
+		{info: "Hosts IPv4 port", configString: "10.0.0.1:9043,10.0.0.2:9043", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"10.0.0.1", "10.0.0.2"}; cfg.Port = 9043 })},
+		{info: "Hosts IPv6 port", configString: "[::1]:9043", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"::1"}; cfg.Port = 9043 })},
+		{info: "Hosts port and bare host", configString: "one:9043,two", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"one", "two"}; cfg.Port = 9043 })},
+		{info: "Hosts port and port", configString: "one:9043?port=9043", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"one"}; cfg.Port = 9043 })},
+		{info: "Hosts IPv6", configString: "2001:DB8:0::1", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"2001:db8::1"} })},
+		{info: "Hosts IPv6 brackets", configString: "[2001:db8::1]", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"2001:db8::1"} })},
+		{info: "Hosts IPv6 brackets port", configString: "[2001:db8::1]:9042", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"2001:db8::1"}; cfg.Port = 9042 })},
+		{info: "Hosts IPv4 IPv6 mix", configString: "10.0.0.1, 2001:db8::1,[2001:db8::2],one", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"10.0.0.1", "2001:db8::1", "2001:db8::2", "one"} })},
+		{info: "Keyspace escaped", configString: "?keyspace=my%26keyspace%3D1", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Keyspace = "my&keyspace=1" })},
+		{info: "Consistency one Keyspace", configString: "?consistency=one&keyspace=system", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = 1; cfg.Keyspace = "system" })},
+		{info: "Host empty settings", configString: "one?", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"one"} })},
 		{info: "Host", configString: "one", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"one"} })},
 		{info: "Hosts", configString: "one,two,three", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"one", "two", "three"} })},
 		{info: "Host & Consistency any", configString: "one?consistency=any", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = 0; cfg.Hosts = []string{"one"} })},
@@ -157,12 +473,29 @@ func TestConfigStringToClusterConfig(t *testing.T) {
 		{info: "PasswordAuthenticator Username", configString: "?username=alice%40bob.com", clusterConfig: cfgWithAuth(gocql.PasswordAuthenticator{Username: "alice@bob.com"})},
 		{info: "PasswordAuthenticator Password", configString: "?password=top%24ecret", clusterConfig: cfgWithAuth(gocql.PasswordAuthenticator{Password: "top$ecret"})},
 		{info: "PasswordAuthenticator", configString: "?username=alice%40bob.com&password=top%24ecret", clusterConfig: cfgWithAuth(gocql.PasswordAuthenticator{Username: "alice@bob.com", Password: "top$ecret"})},
+		// - optional DsePlainTextAuthenticator
+		{info: "DsePlainTextAuthenticator", configString: "?username=alice%40bob.com&password=top%24ecret&dseAuth=plainText", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+			cfg.Authenticator = DsePlainTextAuthenticator{Username: "alice@bob.com", Password: "top$ecret"}
+		})},
+		{info: "DsePlainTextAuthenticator dseAuth first", configString: "?dseAuth=plainText&username=alice", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+			cfg.Authenticator = DsePlainTextAuthenticator{Username: "alice"}
+		})},
 		// - optional SslOptions
 		{info: "SslOptions EnableHostVerification true", configString: "?enableHostVerification=true", clusterConfig: cfgWithSsl(&gocql.SslOptions{EnableHostVerification: true})},
-		{info: "SslOptions CaPath", configString: "?caPath=/some%20path.pem", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/some path.pem"})},
-		{info: "SslOptions CertPath", configString: "?certPath=/some+path.pem", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/some path.pem"})},
+		{info: "SslOptions CaPath", configString: "?caPath=/some%20path.pem", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/some path.pem", EnableHostVerification: true})},
+		{info: "SslOptions CaPath enableHostVerification false", configString: "?caPath=/ca/path&enableHostVerification=false", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path"})},
+		{info: "SslOptions enableHostVerification false CaPath", configString: "?enableHostVerification=false&caPath=/ca/path", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path"})},
+		{info: "SslOptions CertPath", configString: "?certPath=/some+path.pem", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/some path.pem", EnableHostVerification: true})},
+		{info: "SslOptions CertPath enableHostVerification false", configString: "?certPath=/cert/path&enableHostVerification=false", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/cert/path"})},
 		{info: "SslOptions KeyPath", configString: "?keyPath=/some path.pem", clusterConfig: cfgWithSsl(&gocql.SslOptions{KeyPath: "/some path.pem"})},
 		{info: "SslOptions", configString: "?caPath=/ca/path&certPath=/cert/path&keyPath=/key/path&enableHostVerification=1", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", CertPath: "/cert/path", KeyPath: "/key/path", EnableHostVerification: true})},
+		{info: "SslOptions sslInsecureSkipVerify", configString: "?sslInsecureSkipVerify=true", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{InsecureSkipVerify: true}})},
+		{info: "SslOptions sslInsecureSkipVerify certPath keyPath", configString: "?certPath=/cert/path&sslInsecureSkipVerify=true&keyPath=/key/path", clusterConfig: cfgWithSsl(&gocql.SslOptions{CertPath: "/cert/path", KeyPath: "/key/path", Config: &tls.Config{InsecureSkipVerify: true}})},
+		{info: "SslOptions sslMinVersion", configString: "?sslMinVersion=1.2", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}})},
+		{info: "SslOptions sslMinVersion caPath", configString: "?caPath=/ca/path&sslMinVersion=1.2", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", EnableHostVerification: true, Config: &tls.Config{MinVersion: tls.VersionTLS12}})},
+		{info: "SslOptions sslMinVersion caPath enableHostVerification false", configString: "?caPath=/ca/path&enableHostVerification=false&sslMinVersion=1.2", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", Config: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}})},
+		{info: "SslOptions sslMinVersion sslInsecureSkipVerify false enableHostVerification false", configString: "?caPath=/ca/path&enableHostVerification=false&sslInsecureSkipVerify=false&sslMinVersion=1.2", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", Config: &tls.Config{MinVersion: tls.VersionTLS12}})},
+		{info: "SslOptions sslMinVersion sslInsecureSkipVerify caPath", configString: "?sslMinVersion=1.1&sslInsecureSkipVerify=true&caPath=/ca/path", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", Config: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS11}})},
 	}
 
 	for _, test := range tests {
@@ -182,3 +515,551 @@ func TestConfigStringToClusterConfig(t *testing.T) {
 	}
 
 }
+
+func dsnWith(customize func(*DSN)) *DSN {
+	cfg := NewClusterConfig()
+	dsn := &DSN{
+		Hosts:             cfg.Hosts,
+		Port:              cfg.Port,
+		Consistency:       cfg.Consistency,
+		SerialConsistency: cfg.SerialConsistency,
+		Timeout:           cfg.Timeout,
+		ConnectTimeout:    cfg.ConnectTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		NumConns:          cfg.NumConns,
+		PageSize:          cfg.PageSize,
+		ProtoVersion:      cfg.ProtoVersion,
+	}
+	customize(dsn)
+	return dsn
+}
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		info         string
+		configString string
+		dsn          *DSN
+		err          error
+	}{
+		{info: "empty", configString: "", dsn: dsnWith(func(dsn *DSN) {})},
+		{info: "hosts port", configString: "10.0.0.1:9043,10.0.0.2:9043", dsn: dsnWith(func(dsn *DSN) { dsn.Hosts = []string{"10.0.0.1", "10.0.0.2"}; dsn.Port = 9043 })},
+		{info: "keyspace consistency", configString: "?keyspace=system&consistency=localOne&serialConsistency=localSerial", dsn: dsnWith(func(dsn *DSN) {
+			dsn.Keyspace = "system"
+			dsn.Consistency = gocql.LocalOne
+			dsn.SerialConsistency = gocql.LocalSerial
+		})},
+		{info: "serialConsistency local_serial", configString: "?serialConsistency=local_serial", dsn: dsnWith(func(dsn *DSN) { dsn.SerialConsistency = gocql.LocalSerial })},
+		{info: "timeouts", configString: "?timeout=1s&connectTimeout=2s&writeTimeout=3s", dsn: dsnWith(func(dsn *DSN) {
+			dsn.Timeout = time.Second
+			dsn.ConnectTimeout = 2 * time.Second
+			dsn.WriteTimeout = 3 * time.Second
+		})},
+		{info: "numConns pageSize protoVersion", configString: "?numConns=4&pageSize=100&protoVersion=4&compressor=snappy", dsn: dsnWith(func(dsn *DSN) {
+			dsn.NumConns = 4
+			dsn.PageSize = 100
+			dsn.ProtoVersion = 4
+		})},
+		{info: "username password", configString: "?username=alice%40bob.com&password=top%24ecret", dsn: dsnWith(func(dsn *DSN) { dsn.Username = "alice@bob.com"; dsn.Password = "top$ecret" })},
+		{info: "dseAuth", configString: "?username=alice&password=secret&dseAuth=plainText", dsn: dsnWith(func(dsn *DSN) { dsn.Username = "alice"; dsn.Password = "secret" })},
+		{info: "invalid key", configString: "?foo=bar", err: fmt.Errorf("invalid key: foo")},
+		{info: "failed timeout", configString: "?timeout=42", err: fmt.Errorf("failed for: timeout = 42")},
+	}
+
+	for _, test := range tests {
+		dsn, err := ParseDSN(test.configString)
+		if err == nil || test.err == nil {
+			if err != test.err {
+				t.Errorf("ParseDSN error - received: %v - expected: %v - info: %v", err, test.err, test.info)
+				continue
+			}
+		} else if err.Error() != test.err.Error() {
+			t.Errorf("ParseDSN error - received: %v - expected: %v - info: %v", err, test.err, test.info)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		clusterConfig, err := ConfigStringToClusterConfig(test.configString)
+		if err != nil {
+			t.Errorf("ConfigStringToClusterConfig error - received: %v - expected: %v - info: %v", err, nil, test.info)
+			continue
+		}
+		if !reflect.DeepEqual(dsn.ClusterConfig(), clusterConfig) {
+			t.Errorf("ClusterConfig - received: %#v - expected: %#v - info: %v", dsn.ClusterConfig(), clusterConfig, test.info)
+		}
+
+		dsn.clusterConfig = nil
+		if !reflect.DeepEqual(dsn, test.dsn) {
+			t.Errorf("dsn - received: %#v - expected: %#v - info: %v", dsn, test.dsn, test.info)
+		}
+	}
+}
+
+func TestDSNString(t *testing.T) {
+	tests := []struct {
+		configString string
+		expected     string
+	}{
+		{configString: "", expected: "127.0.0.1"},
+		{configString: "10.0.0.1?timeout=1s&keyspace=system&consistency=one", expected: "10.0.0.1?consistency=one&keyspace=system&timeout=1s"},
+		{configString: "10.0.0.1?consistency=one&timeout=1s&keyspace=system", expected: "10.0.0.1?consistency=one&keyspace=system&timeout=1s"},
+		{configString: "10.0.0.1?username=alice&pageSize=10&compressor=snappy&port=9043", expected: "10.0.0.1?compressor=snappy&pageSize=10&port=9043&username=alice"},
+		{configString: "10.0.0.1?consistency=quorum&timeout=-1s&numConns=2", expected: "10.0.0.1"},
+		{configString: "10.0.0.1?username=alice&password=top%24ecret", expected: "10.0.0.1?password=xxxxx&username=alice"},
+		{configString: "10.0.0.1?username=alice&password=secret&dseAuth=plainText", expected: "10.0.0.1?dseAuth=plainText&password=xxxxx&username=alice"},
+	}
+
+	for _, test := range tests {
+		dsn, err := ParseDSN(test.configString)
+		if err != nil {
+			t.Fatalf("ParseDSN error - received: %v - expected: %v - configString: %v", err, nil, test.configString)
+		}
+		// String is the same every time
+		for i := 0; i < 3; i++ {
+			configString := dsn.String()
+			if configString != test.expected {
+				t.Fatalf("String failed for: %v - received: %v - expected: %v", test.configString, configString, test.expected)
+			}
+		}
+		if formatted := fmt.Sprintf("%v", dsn); strings.Contains(formatted, "ecret") {
+			t.Fatalf("Sprintf failed for: %v - received: %v - expected: password redacted", test.configString, formatted)
+		}
+	}
+}
+
+func TestDSNEqual(t *testing.T) {
+	tests := []struct {
+		configString string
+		other        string
+		expected     bool
+	}{
+		{configString: "", other: "127.0.0.1", expected: true},
+		{configString: "?keyspace=system&timeout=1s", other: "?timeout=1s&keyspace=system", expected: true},
+		{configString: "?consistency=quorum&numConns=2&keyspace=system", other: "?keyspace=system", expected: true},
+		{configString: "?keyspace=system", other: "?keyspace=system&timeout=1s", expected: false},
+		{configString: "10.0.0.1", other: "10.0.0.2", expected: false},
+		{configString: "?username=alice&password=one", other: "?password=one&username=alice", expected: true},
+		{configString: "?username=alice&password=one", other: "?username=alice&password=two", expected: false},
+	}
+
+	for _, test := range tests {
+		dsn, err := ParseDSN(test.configString)
+		if err != nil {
+			t.Fatalf("ParseDSN error - received: %v - expected: %v - configString: %v", err, nil, test.configString)
+		}
+		other, err := ParseDSN(test.other)
+		if err != nil {
+			t.Fatalf("ParseDSN error - received: %v - expected: %v - configString: %v", err, nil, test.other)
+		}
+		if dsn.Equal(other) != test.expected || other.Equal(dsn) != test.expected {
+			t.Fatalf("Equal failed for: %v and %v - received: %v - expected: %v", test.configString, test.other, !test.expected, test.expected)
+		}
+	}
+
+	var nilDSN *DSN
+	dsn, _ := ParseDSN("")
+	if nilDSN.Equal(dsn) || dsn.Equal(nil) || !nilDSN.Equal(nil) {
+		t.Fatalf("Equal nil failed")
+	}
+}
+
+func TestDSNClusterConfig(t *testing.T) {
+	dsn, err := ParseDSN("10.0.0.1?keyspace=one&compressor=snappy&username=alice&password=secret")
+	if err != nil {
+		t.Fatalf("ParseDSN error - received: %v - expected: %v ", err, nil)
+	}
+
+	dsn.Hosts = append(dsn.Hosts, "10.0.0.2")
+	dsn.Keyspace = "two"
+	dsn.Consistency = gocql.One
+	dsn.Username = ""
+	dsn.Password = ""
+
+	clusterConfig := dsn.ClusterConfig()
+	expected := cfgWith(func(cfg *gocql.ClusterConfig) {
+		cfg.Hosts = []string{"10.0.0.1", "10.0.0.2"}
+		cfg.Keyspace = "two"
+		cfg.Consistency = gocql.One
+		cfg.Compressor = gocql.SnappyCompressor{}
+	})
+	if !reflect.DeepEqual(clusterConfig, expected) {
+		t.Fatalf("ClusterConfig - received: %#v - expected: %#v ", clusterConfig, expected)
+	}
+
+	var zero DSN
+	clusterConfig = zero.ClusterConfig()
+	if len(clusterConfig.Hosts) != 1 || clusterConfig.Hosts[0] != "127.0.0.1" {
+		t.Fatalf("Hosts - received: %v - expected: %v ", clusterConfig.Hosts, []string{"127.0.0.1"})
+	}
+	expected = NewClusterConfig()
+	if !reflect.DeepEqual(clusterConfig, expected) {
+		t.Fatalf("ClusterConfig - received: %#v - expected: %#v ", clusterConfig, expected)
+	}
+
+	// partially filled DSN keeps the defaults of the other settings
+	partial := DSN{Hosts: []string{"10.0.0.1"}, Keyspace: "system", Timeout: time.Second, Username: "alice", Password: "secret"}
+	clusterConfig = partial.ClusterConfig()
+	expected = NewClusterConfig("10.0.0.1")
+	expected.Keyspace = "system"
+	expected.Timeout = time.Second
+	expected.Authenticator = gocql.PasswordAuthenticator{Username: "alice", Password: "secret"}
+	if !reflect.DeepEqual(clusterConfig, expected) {
+		t.Fatalf("ClusterConfig - received: %#v - expected: %#v ", clusterConfig, expected)
+	}
+	configString := partial.String()
+	if configString != "10.0.0.1?keyspace=system&password=xxxxx&timeout=1s&username=alice" {
+		t.Fatalf("String - received: %v - expected: %v ", configString, "10.0.0.1?keyspace=system&password=xxxxx&timeout=1s&username=alice")
+	}
+
+	// partially changed DSN keeps the other settings of the config string
+	dsn, err = ParseDSN("10.0.0.1?consistency=one&timeout=2s&port=9043&pageSize=100")
+	if err != nil {
+		t.Fatalf("ParseDSN error - received: %v - expected: %v ", err, nil)
+	}
+	dsn.Keyspace = "system"
+	dsn.Timeout = 0
+	clusterConfig = dsn.ClusterConfig()
+	expected = cfgWith(func(cfg *gocql.ClusterConfig) {
+		cfg.Hosts = []string{"10.0.0.1"}
+		cfg.Keyspace = "system"
+		cfg.Consistency = gocql.One
+		cfg.Timeout = 2 * time.Second
+		cfg.Port = 9043
+		cfg.PageSize = 100
+	})
+	if !reflect.DeepEqual(clusterConfig, expected) {
+		t.Fatalf("ClusterConfig - received: %#v - expected: %#v ", clusterConfig, expected)
+	}
+}
+
+// testDSNSessionConfigStrings are config strings of host selection policies that must not be shared by sessions
+var testDSNSessionConfigStrings = []string{
+	"127.0.0.1?port=1&connectTimeout=100ms&hostSelectionPolicy=roundrobin",
+	"127.0.0.1?port=1&connectTimeout=100ms&hostSelectionPolicy=tokenaware",
+	"127.0.0.1?port=1&connectTimeout=100ms&hostSelectionPolicy=tokenaware&shuffleReplicas=true",
+	"127.0.0.1?port=1&connectTimeout=100ms&hostSelectionPolicy=tokenaware,dcaware&localDC=dc1&allowRemoteDCsForLocalConsistency=true",
+	"127.0.0.1?port=1&connectTimeout=100ms&localDC=dc1",
+}
+
+func TestDSNClusterConfigSessions(t *testing.T) {
+	for _, test := range testDSNSessionConfigStrings {
+		dsn, err := ParseDSN(test)
+		if err != nil {
+			t.Fatalf("ParseDSN error - received: %v - expected: %v - configString: %v", err, nil, test)
+		}
+
+		// a session that is not created still initializes the policy, sharing a token aware policy panics
+		for i := 0; i < 2; i++ {
+			clusterConfig := dsn.ClusterConfig()
+			session, err := clusterConfig.CreateSession()
+			if err == nil {
+				session.Close()
+			}
+		}
+	}
+}
+
+func TestClusterConfigToConfigStringKeyOrder(t *testing.T) {
+	clusterConfig := cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", EnableHostVerification: true})
+	clusterConfig.Hosts = []string{"10.0.0.2", "10.0.0.1"}
+	clusterConfig.Timeout = time.Second
+	clusterConfig.Keyspace = "system"
+	clusterConfig.Consistency = gocql.One
+	clusterConfig.SerialConsistency = gocql.LocalSerial
+	clusterConfig.PageSize = 100
+	clusterConfig.ProtoVersion = 4
+	clusterConfig.Port = 9043
+	clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: "alice", Password: "secret"}
+
+	expected := "10.0.0.2,10.0.0.1?caPath=%2Fca%2Fpath&consistency=one&enableHostVerification=true&keyspace=system&pageSize=100&password=secret&port=9043&protoVersion=4&serialConsistency=localSerial&timeout=1s&username=alice"
+	configString := ClusterConfigToConfigString(clusterConfig)
+	if configString != expected {
+		t.Fatalf("ClusterConfigToConfigString - received: %v - expected: %v ", configString, expected)
+	}
+
+	keys := strings.Split(strings.SplitN(configString, "?", 2)[1], "&")
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Fatalf("key order - received: %v before %v - expected: alphabetical order", keys[i-1], keys[i])
+		}
+	}
+}
+
+func TestConfigBuilder(t *testing.T) {
+	tests := []struct {
+		info          string
+		builder       *ConfigBuilder
+		configString  string
+		clusterConfig *gocql.ClusterConfig
+	}{
+		{info: "default", builder: NewConfigBuilder(), configString: "127.0.0.1", clusterConfig: NewClusterConfig()},
+		{info: "hosts port keyspace", builder: NewConfigBuilder().Hosts("10.0.0.1", "10.0.0.2").Port(9043).Keyspace("system"),
+			configString: "10.0.0.1,10.0.0.2?keyspace=system&port=9043",
+			clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+				cfg.Hosts = []string{"10.0.0.1", "10.0.0.2"}
+				cfg.Port = 9043
+				cfg.Keyspace = "system"
+			})},
+		{info: "consistency timeouts", builder: NewConfigBuilder().Consistency(gocql.LocalOne).SerialConsistency(gocql.LocalSerial).Timeout(time.Second).ConnectTimeout(2 * time.Second),
+			configString: "127.0.0.1?connectTimeout=2s&consistency=localOne&serialConsistency=localSerial&timeout=1s",
+			clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+				cfg.Consistency = gocql.LocalOne
+				cfg.SerialConsistency = gocql.LocalSerial
+				cfg.Timeout = time.Second
+				cfg.ConnectTimeout = 2 * time.Second
+			})},
+		{info: "numConns pageSize protoVersion", builder: NewConfigBuilder().NumConns(4).PageSize(100).ProtoVersion(4),
+			configString: "127.0.0.1?numConns=4&pageSize=100&protoVersion=4",
+			clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+				cfg.NumConns = 4
+				cfg.PageSize = 100
+				cfg.ProtoVersion = 4
+			})},
+		{info: "auth", builder: NewConfigBuilder().WithAuth("alice@bob.com", "top$ecret&"),
+			configString:  "127.0.0.1?password=top%24ecret%26&username=alice%40bob.com",
+			clusterConfig: cfgWithAuth(gocql.PasswordAuthenticator{Username: "alice@bob.com", Password: "top$ecret&"})},
+		{info: "ssl", builder: NewConfigBuilder().WithSSL("/ca/path", "", "", true),
+			configString:  "127.0.0.1?caPath=%2Fca%2Fpath&enableHostVerification=true",
+			clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", EnableHostVerification: true})},
+		{info: "ssl no host verification", builder: NewConfigBuilder().WithSSL("/ca/path", "/cert/path", "/key/path", false),
+			configString:  "127.0.0.1?caPath=%2Fca%2Fpath&certPath=%2Fcert%2Fpath&enableHostVerification=false&keyPath=%2Fkey%2Fpath",
+			clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", CertPath: "/cert/path", KeyPath: "/key/path"})},
+	}
+
+	for _, test := range tests {
+		configString, err := test.builder.Build()
+		if err != nil {
+			t.Errorf("Build error - received: %v - expected: %v - info: %v", err, nil, test.info)
+			continue
+		}
+		if configString != test.configString {
+			t.Errorf("Build - received: %v - expected: %v - info: %v", configString, test.configString, test.info)
+		}
+		clusterConfig, err := ConfigStringToClusterConfig(configString)
+		if err != nil {
+			t.Errorf("ConfigStringToClusterConfig error - received: %v - expected: %v - info: %v", err, nil, test.info)
+			continue
+		}
+		if !reflect.DeepEqual(clusterConfig, test.clusterConfig) {
+			t.Errorf("clusterConfig - received: %#v - expected: %#v - info: %v", clusterConfig, test.clusterConfig, test.info)
+		}
+	}
+
+	errorTests := []struct {
+		info    string
+		builder *ConfigBuilder
+		err     error
+	}{
+		{info: "port", builder: NewConfigBuilder().Port(70000), err: fmt.Errorf("failed for: port = 70000")},
+		{info: "protoVersion", builder: NewConfigBuilder().ProtoVersion(9), err: fmt.Errorf("failed for: protoVersion = 9")},
+		{info: "consistency", builder: NewConfigBuilder().Consistency(gocql.Consistency(gocql.Serial)),
+			err: fmt.Errorf("clusterConfig.Consistency value not found in DbConsistency: %v", gocql.Consistency(gocql.Serial))},
+	}
+
+	for _, test := range errorTests {
+		configString, err := test.builder.Build()
+		if err == nil || err.Error() != test.err.Error() {
+			t.Errorf("Build error - received: %v - expected: %v - info: %v", err, test.err, test.info)
+		}
+		if configString != "" {
+			t.Errorf("Build - received: %v - expected: %v - info: %v", configString, "", test.info)
+		}
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	tests := []struct {
+		info          string
+		clusterConfig *gocql.ClusterConfig
+	}{
+		{info: "default", clusterConfig: NewClusterConfig()},
+		{info: "Port", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Port = 9043 })},
+		{info: "ProtoVersion", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ProtoVersion = 4 })},
+		{info: "CQLVersion", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.CQLVersion = "3&4=0" })},
+		{info: "PageSize 100", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PageSize = 100 })},
+		{info: "PageSize 0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PageSize = 0 })},
+		{info: "SerialConsistency", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.LocalSerial })},
+		{info: "DefaultTimestamp true", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultTimestamp = true })},
+		{info: "DefaultTimestamp false", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultTimestamp = false })},
+		{info: "ReconnectInterval", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.ReconnectInterval = 10 * time.Second })},
+		{info: "MaxWaitSchemaAgreement", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.MaxWaitSchemaAgreement = 120 * time.Second })},
+		{info: "SocketKeepalive", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SocketKeepalive = 15 * time.Second })},
+		{info: "connectConcurrency", clusterConfig: cfgWithConnectConcurrency(2, func(cfg *gocql.ClusterConfig) { cfg.ConnectTimeout = 3 * time.Second })},
+		{info: "MaxRoutingKeyInfo", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.MaxRoutingKeyInfo = 500 })},
+		{info: "Timeout WriteTimeout", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Timeout = time.Second; cfg.WriteTimeout = 5 * time.Second })},
+		{info: "DefaultIdempotence", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DefaultIdempotence = true })},
+		{info: "DisableSkipMetadata", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.DisableSkipMetadata = true })},
+		{info: "Compressor", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Compressor = gocql.SnappyCompressor{} })},
+		{info: "RetryPolicy", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.RetryPolicy = &gocql.SimpleRetryPolicy{NumRetries: 3} })},
+		{info: "ReconnectionPolicy constant", clusterConfig: cfgWithReconnectionPolicy(&gocql.ConstantReconnectionPolicy{MaxRetries: 5, Interval: 2 * time.Second})},
+		{info: "ReconnectionPolicy exponential", clusterConfig: cfgWithReconnectionPolicy(&gocql.ExponentialReconnectionPolicy{MaxRetries: 5, InitialInterval: 500 * time.Millisecond, MaxInterval: time.Minute})},
+		{info: "HostSelectionPolicy roundrobin", clusterConfig: cfgWithHostSelectionPolicy("roundrobin", "")},
+		{info: "HostSelectionPolicy tokenaware", clusterConfig: cfgWithHostSelectionPolicy("tokenaware", "")},
+		{info: "HostSelectionPolicy tokenaware shuffleReplicas", clusterConfig: cfgWithTokenAware("tokenaware", "", true, false)},
+		{info: "HostSelectionPolicy tokenaware,dcaware shuffleReplicas", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc 1", true, false)},
+		{info: "HostSelectionPolicy tokenaware,dcaware allowRemoteDCs", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc 1", false, true)},
+		{info: "HostSelectionPolicy tokenaware,dcaware shuffleReplicas allowRemoteDCs", clusterConfig: cfgWithTokenAware("tokenaware,dcaware", "dc 1", true, true)},
+		{info: "HostSelectionPolicy tokenaware,dcaware", clusterConfig: cfgWithHostSelectionPolicy("tokenaware,dcaware", "dc 1")},
+		{info: "HostSelectionPolicy localDC", clusterConfig: cfgWithHostSelectionPolicy("", "us-east-1")},
+		{info: "SslOptions sslInsecureSkipVerify", clusterConfig: cfgWithSsl(&gocql.SslOptions{CaPath: "/ca/path", Config: &tls.Config{InsecureSkipVerify: true}})},
+		{info: "SslOptions sslMinVersion", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{MinVersion: tls.VersionTLS11}})},
+		{info: "AddressTranslator", clusterConfig: cfgWithAddressTranslator("10.0.0.1:1.2.3.4,10.0.0.2:1.2.3.5")},
+		{info: "HostFilter", clusterConfig: cfgWithHostFilter([]string{"dc1", "dc,2"}, "10.0.0.1", "2001:db8::1")},
+		{info: "Hosts IPv4 IPv6", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Hosts = []string{"10.0.0.1", "2001:db8::1"} })},
+		{info: "Keyspace", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Keyspace = "my&keyspace=1" })},
+		{info: "WriteCoalesceWaitTime 0", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.WriteCoalesceWaitTime = 0 })},
+		{info: "DisableNodeStatusEvents", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableNodeStatusEvents = true })},
+		{info: "DisableTopologyEvents", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableTopologyEvents = true })},
+		{info: "DisableSchemaEvents", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Events.DisableSchemaEvents = true })},
+		{info: "DsePlainTextAuthenticator", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) {
+			cfg.Authenticator = DsePlainTextAuthenticator{Username: "alice@bob.com", Password: "top$ecret"}
+		})},
+	}
+
+	for _, test := range tests {
+		err := RoundTrip(test.clusterConfig)
+		if err != nil {
+			t.Errorf("RoundTrip error - received: %v - expected: %v - info: %v", err, nil, test.info)
+		}
+	}
+}
+
+func TestConfigRoundTripSsl(t *testing.T) {
+	paths := []string{"", "/some path.pem", "/some+path&key=.pem"}
+	tlsConfigs := []*tls.Config{nil, {}, {InsecureSkipVerify: true}, {MinVersion: tls.VersionTLS12}, {InsecureSkipVerify: true, MinVersion: tls.VersionTLS11}}
+
+	for _, enableHostVerification := range []bool{false, true} {
+		for _, caPath := range paths {
+			for _, certPath := range paths {
+				for _, keyPath := range paths {
+					for _, tlsConfig := range tlsConfigs {
+						sslOpts := &gocql.SslOptions{
+							EnableHostVerification: enableHostVerification,
+							CaPath:                 caPath,
+							CertPath:               certPath,
+							KeyPath:                keyPath,
+						}
+						if tlsConfig != nil {
+							sslOpts.Config = tlsConfig.Clone()
+						}
+						err := RoundTrip(cfgWithSsl(sslOpts))
+						if err != nil {
+							t.Errorf("RoundTrip error - received: %v - expected: %v - info: %#v", err, nil, sslOpts)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	err := RoundTrip(cfgWith(func(cfg *gocql.ClusterConfig) { cfg.PoolConfig.HostSelectionPolicy = gocql.RoundRobinHostPolicy() }))
+	if err == nil {
+		t.Fatalf("RoundTrip error - received: %v - expected: %v", err, "round trip not equivalent")
+	}
+}
+
+func TestConfigRoundTripError(t *testing.T) {
+	tests := []struct {
+		info          string
+		clusterConfig *gocql.ClusterConfig
+		err           error
+	}{
+		{info: "Consistency", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.Consistency = gocql.Consistency(0x99) }),
+			err: fmt.Errorf("ClusterConfigToConfigString error: clusterConfig.Consistency value not found in DbConsistency: %v", gocql.Consistency(0x99))},
+		{info: "SerialConsistency", clusterConfig: cfgWith(func(cfg *gocql.ClusterConfig) { cfg.SerialConsistency = gocql.SerialConsistency(0x99) }),
+			err: fmt.Errorf("ClusterConfigToConfigString error: clusterConfig.SerialConsistency value not found in DbSerialConsistency: %v", gocql.SerialConsistency(0x99))},
+		{info: "SslOptions MinVersion", clusterConfig: cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{MinVersion: 0x99}}),
+			err: fmt.Errorf("ClusterConfigToConfigString error: clusterConfig.SslOpts.MinVersion value not found in DbSslVersion: 153")},
+	}
+
+	for _, test := range tests {
+		err := RoundTrip(test.clusterConfig)
+		if err == nil || err.Error() != test.err.Error() {
+			t.Fatalf("RoundTrip error - received: %v - expected: %v - info: %v", err, test.err, test.info)
+		}
+	}
+
+	// a DSN with a setting that can not be in a config string is an empty string
+	dsn := &DSN{Consistency: gocql.Consistency(0x99)}
+	if dsn.String() != "" {
+		t.Fatalf("String - received: %v - expected: %v ", dsn.String(), "")
+	}
+}
+
+func TestAddressTranslator(t *testing.T) {
+	translator, err := newAddressTranslator("10.0.0.1:1.2.3.4,10.0.0.2:1.2.3.5")
+	if err != nil {
+		t.Fatalf("newAddressTranslator error - received: %v - expected: %v ", err, nil)
+	}
+
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{addr: "10.0.0.1", expected: "1.2.3.4"},
+		{addr: "10.0.0.2", expected: "1.2.3.5"},
+		{addr: "10.0.0.3", expected: "10.0.0.3"},
+	}
+	for _, test := range tests {
+		addr, port := translator.Translate(net.ParseIP(test.addr), 9042)
+		if !addr.Equal(net.ParseIP(test.expected)) {
+			t.Errorf("Translate addr - received: %v - expected: %v", addr, test.expected)
+		}
+		if port != 9042 {
+			t.Errorf("Translate port - received: %v - expected: %v", port, 9042)
+		}
+	}
+}
+
+func TestDsePlainTextAuthenticator(t *testing.T) {
+	authenticator := DsePlainTextAuthenticator{Username: "alice", Password: "secret"}
+	credentials := []byte("\x00alice\x00secret")
+
+	// DseAuthenticator
+	resp, challenger, err := authenticator.Challenge([]byte("com.datastax.bdp.cassandra.auth.DseAuthenticator"))
+	if err != nil {
+		t.Fatalf("Challenge error - received: %v - expected: %v ", err, nil)
+	}
+	if string(resp) != "PLAIN" {
+		t.Fatalf("Challenge - received: %q - expected: %q ", resp, "PLAIN")
+	}
+	if challenger == nil {
+		t.Fatal("challenger is nil")
+	}
+	resp, next, err := challenger.Challenge([]byte("PLAIN-START"))
+	if err != nil {
+		t.Fatalf("Challenge error - received: %v - expected: %v ", err, nil)
+	}
+	if string(resp) != string(credentials) {
+		t.Fatalf("Challenge - received: %q - expected: %q ", resp, credentials)
+	}
+	if next != nil {
+		t.Fatalf("Challenge - received: %v - expected: %v ", next, nil)
+	}
+	_, _, err = challenger.Challenge([]byte("GSSAPI-START"))
+	expectedError := "unexpected dse challenge: \"GSSAPI-START\""
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Challenge error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = challenger.Success(nil)
+	if err != nil {
+		t.Fatalf("Success error - received: %v - expected: %v ", err, nil)
+	}
+
+	// other authenticators
+	resp, challenger, err = authenticator.Challenge([]byte("org.apache.cassandra.auth.PasswordAuthenticator"))
+	if err != nil {
+		t.Fatalf("Challenge error - received: %v - expected: %v ", err, nil)
+	}
+	if string(resp) != string(credentials) {
+		t.Fatalf("Challenge - received: %q - expected: %q ", resp, credentials)
+	}
+	if challenger != nil {
+		t.Fatalf("Challenge - received: %v - expected: %v ", challenger, nil)
+	}
+	err = authenticator.Success(nil)
+	if err != nil {
+		t.Fatalf("Success error - received: %v - expected: %v ", err, nil)
+	}
+}
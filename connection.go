@@ -3,10 +3,29 @@ package cql
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
 )
 
-// Close a database connection
+// Close a database connection, after which Ping returns driver.ErrBadConn.
+// A session borrowed with NewConnectorFromSession is not closed.
 func (cqlConn *cqlConnStruct) Close() error {
+	cqlConn.closed = true
+	if cqlConn.borrowed {
+		cqlConn.session = nil
+		return nil
+	}
+	return cqlConn.closeSession()
+}
+
+// closeSession closes the session, a new session is created on the next Ping.
+// A borrowed session is kept so the connection keeps using it.
+func (cqlConn *cqlConnStruct) closeSession() error {
+	if cqlConn.borrowed {
+		return nil
+	}
 	if cqlConn.session != nil {
 		cqlConn.session.Close()
 		cqlConn.session = nil
@@ -18,10 +37,19 @@ func (cqlConn *cqlConnStruct) Close() error {
 func (cqlConn *cqlConnStruct) Ping(ctx context.Context) error {
 	var err error
 
+	if cqlConn.closed {
+		return driver.ErrBadConn
+	}
+	if cqlConn.session != nil && cqlConn.session.Closed() {
+		cqlConn.closeSession()
+		cqlConn.logger.Print("Ping session closed")
+		return driver.ErrBadConn
+	}
+
 	if cqlConn.session == nil {
-		cqlConn.session, err = cqlConn.clusterConfig.CreateSession()
+		cqlConn.session, err = cqlConn.createSession(ctx)
 		if err != nil {
-			cqlConn.Close()
+			cqlConn.closeSession()
 			cqlConn.logger.Print("Ping CreateSession error: ", err)
 			return driver.ErrBadConn
 		}
@@ -33,13 +61,13 @@ func (cqlConn *cqlConnStruct) Ping(ctx context.Context) error {
 	rowData, err := iter.RowData()
 	if err != nil {
 		iter.Close()
-		cqlConn.Close()
+		cqlConn.closeSession()
 		cqlConn.logger.Print("Ping RowData error: ", err)
 		return driver.ErrBadConn
 	}
 	if len(rowData.Values) != 1 {
 		iter.Close()
-		cqlConn.Close()
+		cqlConn.closeSession()
 		cqlConn.logger.Print("Ping len(Values) != 1")
 		return driver.ErrBadConn
 	}
@@ -47,28 +75,28 @@ func (cqlConn *cqlConnStruct) Ping(ctx context.Context) error {
 	if !iter.Scan(rowData.Values...) {
 		err = iter.Close()
 		if err != nil {
-			cqlConn.Close()
+			cqlConn.closeSession()
 		} else {
-			err = cqlConn.Close()
+			err = cqlConn.closeSession()
 		}
 		cqlConn.logger.Print("Ping Scan error: ", err)
 		return driver.ErrBadConn
 	}
 	err = iter.Close()
 	if err != nil {
-		cqlConn.Close()
+		cqlConn.closeSession()
 		cqlConn.logger.Print("Ping iter Close error: ", err)
 		return driver.ErrBadConn
 	}
 
 	data, ok := rowData.Values[0].(*string)
 	if !ok {
-		cqlConn.Close()
+		cqlConn.closeSession()
 		cqlConn.logger.Print("Ping Value not *string")
 		return driver.ErrBadConn
 	}
 	if len(*data) < 1 {
-		cqlConn.Close()
+		cqlConn.closeSession()
 		cqlConn.logger.Print("Ping len(data) < 1")
 		return driver.ErrBadConn
 	}
@@ -76,6 +104,156 @@ func (cqlConn *cqlConnStruct) Ping(ctx context.Context) error {
 	return nil
 }
 
+// createSession creates a session, with eagerPoolFill it waits up to ConnectTimeout for NumConns connections to each host.
+// Each session gets its own host selection policy built from the config string settings.
+func (cqlConn *cqlConnStruct) createSession(ctx context.Context) (*gocql.Session, error) {
+	clusterConfig := sessionClusterConfig(cqlConn.clusterConfig)
+	if !cqlConn.eagerPoolFill {
+		return clusterConfig.CreateSession()
+	}
+
+	observer := &poolFillObserver{
+		observer: clusterConfig.ConnectObserver,
+		hosts:    make(map[string]struct{}),
+		changed:  make(chan struct{}, 1),
+	}
+	clusterConfig.ConnectObserver = observer
+	session, err := clusterConfig.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	// the control connection, and the protocol discovery connection without protoVersion, are not pool connections
+	extra := 1
+	if clusterConfig.ProtoVersion == 0 {
+		extra++
+	}
+	timer := time.NewTimer(clusterConfig.ConnectTimeout)
+	defer timer.Stop()
+	for !observer.filled(clusterConfig.NumConns, extra) {
+		select {
+		case <-observer.changed:
+		case <-timer.C:
+			cqlConn.logger.Print("Ping pool fill timeout")
+			return session, nil
+		case <-ctx.Done():
+			return session, nil
+		}
+	}
+	return session, nil
+}
+
+// ObserveConnect counts the connections made and calls the ClusterConfig ConnectObserver
+func (observer *poolFillObserver) ObserveConnect(observedConnect gocql.ObservedConnect) {
+	if observedConnect.Err == nil && observedConnect.Host != nil {
+		observer.mutex.Lock()
+		observer.hosts[observedConnect.Host.ConnectAddressAndPort()] = struct{}{}
+		observer.connected++
+		observer.mutex.Unlock()
+		select {
+		case observer.changed <- struct{}{}:
+		default:
+		}
+	}
+	if observer.observer != nil {
+		observer.observer.ObserveConnect(observedConnect)
+	}
+}
+
+// filled returns true when numConns connections to each host, and extra connections, have been made
+func (observer *poolFillObserver) filled(numConns int, extra int) bool {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	return len(observer.hosts) > 0 && observer.connected >= len(observer.hosts)*numConns+extra
+}
+
+// ResetSession clears the connection overrides, like the consistency set by SetConsistency.
+// Returns driver.ErrBadConn if the connection or its session is closed.
+func (cqlConn *cqlConnStruct) ResetSession(ctx context.Context) error {
+	if cqlConn.closed {
+		return driver.ErrBadConn
+	}
+	if cqlConn.session != nil && cqlConn.session.Closed() {
+		return driver.ErrBadConn
+	}
+	cqlConn.consistency = nil
+	cqlConn.tx = nil
+	return nil
+}
+
+// IsValid returns false when the connection or its session is closed,
+// or an operation returned a fatal connection error, so database/sql discards the connection
+func (cqlConn *cqlConnStruct) IsValid() bool {
+	if cqlConn.closed || cqlConn.invalid {
+		return false
+	}
+	if cqlConn.session != nil && cqlConn.session.Closed() {
+		return false
+	}
+	return true
+}
+
+// protoVersion returns the protocol version of the cluster config, 0 when unknown
+func (cqlConn *cqlConnStruct) protoVersion() int {
+	if cqlConn.clusterConfig == nil {
+		return 0
+	}
+	return cqlConn.clusterConfig.ProtoVersion
+}
+
+// checkFatalError marks the connection invalid if err is a fatal connection error
+func (cqlConn *cqlConnStruct) checkFatalError(err error) {
+	if cqlConn != nil && isFatalError(err) {
+		cqlConn.invalid = true
+	}
+}
+
+// SetConsistency overrides the consistency of queries executed on the connection until ResetSession
+func (cqlConn *cqlConnStruct) SetConsistency(consistency gocql.Consistency) {
+	cqlConn.consistency = &consistency
+}
+
+// applyOverrides applies the connection overrides to the query.
+// The default read or write consistency is applied by the statement verb when SetConsistency is not set.
+func (cqlConn *cqlConnStruct) applyOverrides(query *gocql.Query) *gocql.Query {
+	if cqlConn.consistency != nil {
+		return query.Consistency(*cqlConn.consistency)
+	}
+	if cqlConn.readConsistency == nil && cqlConn.writeConsistency == nil {
+		return query
+	}
+	switch statementVerb(query.Statement()) {
+	case "select":
+		if cqlConn.readConsistency != nil {
+			query = query.Consistency(*cqlConn.readConsistency)
+		}
+	case "insert", "update", "delete", "begin":
+		if cqlConn.writeConsistency != nil {
+			query = query.Consistency(*cqlConn.writeConsistency)
+		}
+	}
+	return query
+}
+
+// applyBatchOverrides applies the connection overrides to the batch.
+// A batch only has mutation statements, so the default write consistency is applied when SetConsistency is not set.
+func (cqlConn *cqlConnStruct) applyBatchOverrides(batch *gocql.Batch) *gocql.Batch {
+	if cqlConn.consistency != nil {
+		batch.SetConsistency(*cqlConn.consistency)
+	} else if cqlConn.writeConsistency != nil {
+		batch.SetConsistency(*cqlConn.writeConsistency)
+	}
+	return batch
+}
+
+// queryStatement returns the statement to query, unprepared when the prepare cache is disabled
+func (cqlConn *cqlConnStruct) queryStatement(statement string) string {
+	if cqlConn.disablePrepareCache {
+		return unpreparedStatement(statement)
+	}
+	return statement
+}
+
 // Prepare a query, uses connection conntext
 func (cqlConn *cqlConnStruct) Prepare(query string) (driver.Stmt, error) {
 	return cqlConn.PrepareContext(cqlConn.context, query)
@@ -93,16 +271,238 @@ func (cqlConn *cqlConnStruct) PrepareContext(ctx context.Context, query string)
 	}
 
 	return &CqlStmt{
-		CqlQuery: cqlConn.session.Query(query).WithContext(ctx),
+		CqlQuery: cqlConn.applyOverrides(cqlConn.session.Query(cqlConn.queryStatement(query)).WithContext(ctx)),
+		session:  cqlConn.session,
+		conn:     cqlConn,
 	}, nil
 }
 
-// Begin not supported
+// QueryContext queries with context without preparing a statement.
+// Returns driver.ErrSkip when values can not be bound so database/sql falls back to PrepareContext.
+// A query of semicolon separated statements has a result set for each statement, use sql.Rows NextResultSet.
+// Each statement is executed when its result set is reached, statements that are not select statements have empty result sets.
+// Values can not be used with multiple statements.
+func (cqlConn *cqlConnStruct) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if cqlConn.session == nil {
+		err := cqlConn.Ping(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	statements := splitStatements(query)
+	if len(statements) > 1 {
+		if len(args) > 0 {
+			return nil, ErrMultipleStatementsValues
+		}
+		cqlStmt := &CqlStmt{
+			CqlQuery: cqlConn.applyOverrides(cqlConn.session.Query(cqlConn.queryStatement(statements[0]))),
+			session:  cqlConn.session,
+			conn:     cqlConn,
+		}
+		rows, err := cqlStmt.queryContext(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		rows.(*cqlRowsStruct).context = ctx
+		rows.(*cqlRowsStruct).statements = statements[1:]
+		return rows, nil
+	}
+
+	cqlStmt := &CqlStmt{
+		CqlQuery: cqlConn.applyOverrides(cqlConn.session.Query(cqlConn.queryStatement(query))),
+		session:  cqlConn.session,
+		conn:     cqlConn,
+	}
+	values, err := cqlStmt.bindValues(args)
+	if err != nil {
+		cqlStmt.Close()
+		return nil, driver.ErrSkip
+	}
+	return cqlStmt.queryContext(ctx, values)
+}
+
+// ExecContext executes with context without preparing a statement.
+// Returns driver.ErrSkip when values can not be bound so database/sql falls back to PrepareContext.
+// Semicolon separated statements are executed in order, stopping at the first error.
+// Values can not be used with multiple statements.
+// Use WithSchemaVersion to get the schema version after DDL statements.
+func (cqlConn *cqlConnStruct) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if cqlConn.session == nil {
+		err := cqlConn.Ping(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	statements := splitStatements(query)
+	if len(statements) > 1 {
+		if len(args) > 0 {
+			return nil, ErrMultipleStatementsValues
+		}
+		for i := 0; i < len(statements); i++ {
+			cqlStmt := &CqlStmt{
+				CqlQuery: cqlConn.applyOverrides(cqlConn.session.Query(cqlConn.queryStatement(statements[i]))),
+				session:  cqlConn.session,
+				conn:     cqlConn,
+			}
+			_, err := cqlStmt.execContext(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return cqlResultStruct{}, nil
+	}
+
+	cqlStmt := &CqlStmt{
+		CqlQuery: cqlConn.applyOverrides(cqlConn.session.Query(cqlConn.queryStatement(query))),
+		session:  cqlConn.session,
+		conn:     cqlConn,
+	}
+	values, err := cqlStmt.bindValues(args)
+	if err != nil {
+		cqlStmt.Close()
+		return nil, driver.ErrSkip
+	}
+	return cqlStmt.execContext(ctx, values)
+}
+
+// BatchExec executes statements in a batch of batchType, which is gocql LoggedBatch, UnloggedBatch, or CounterBatch.
+// Counter updates, like update set c = c + ?, can only be in a CounterBatch and a CounterBatch can only have counter updates.
+// Counter updates are not idempotent, a retried counter batch can be applied more than once.
+func (cqlConn *cqlConnStruct) BatchExec(ctx context.Context, batchType gocql.BatchType, statements []BatchStatement) error {
+	switch batchType {
+	case gocql.LoggedBatch, gocql.UnloggedBatch, gocql.CounterBatch:
+	default:
+		return fmt.Errorf("invalid batch type: %v", batchType)
+	}
+	if len(statements) < 1 {
+		return ErrBatchIsEmpty
+	}
+
+	if cqlConn.session == nil {
+		err := cqlConn.Ping(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	batch := applyBatchContext(ctx, cqlConn.session, cqlConn.applyBatchOverrides(cqlConn.session.NewBatch(batchType).WithContext(ctx)))
+	for i := 0; i < len(statements); i++ {
+		batch.Query(statements[i].Statement, statements[i].Values...)
+	}
+
+	err := cqlConn.session.ExecuteBatch(batch)
+	if err != nil {
+		cqlConn.checkFatalError(err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ExecCAS executes a lightweight transaction, an insert, update, or delete statement with an if clause.
+// Returns whether it was applied and, when not applied, the existing values by column name as scanned by gocql.
+// Returns ErrTxQueryNotSupported during a transaction.
+func (cqlConn *cqlConnStruct) ExecCAS(ctx context.Context, statement string, values ...interface{}) (bool, map[string]interface{}, error) {
+	if cqlConn.tx != nil {
+		return false, nil, ErrTxQueryNotSupported
+	}
+	if cqlConn.session == nil {
+		err := cqlConn.Ping(ctx)
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	err := checkContext(ctx, cqlConn.protoVersion())
+	if err != nil {
+		return false, nil, err
+	}
+
+	query := applyContext(ctx, cqlConn.session, cqlConn.applyOverrides(cqlConn.session.Query(cqlConn.queryStatement(statement), values...).WithContext(ctx)))
+	// the result columns depend on whether it was applied, so always get the result metadata
+	iter := query.NoSkipMetadata().Iter()
+	setWarnings(ctx, iter)
+	setResponseCustomPayload(ctx, iter)
+	setQueryStats(ctx, query, iter)
+	existing := make(map[string]interface{})
+	iter.MapScan(existing)
+	err = iter.Close()
+	query.Release()
+	if err != nil {
+		cqlConn.checkFatalError(err)
+		if ctx.Err() != nil {
+			return false, nil, ctx.Err()
+		}
+		return false, nil, err
+	}
+
+	applied, ok := existing["[applied]"].(bool)
+	if !ok {
+		return false, nil, ErrNotLightweightTransaction
+	}
+	delete(existing, "[applied]")
+
+	return applied, existing, nil
+}
+
+// AwaitSchemaAgreement waits for all nodes to agree on the schema version, like after DDL statements,
+// up to the ClusterConfig MaxWaitSchemaAgreement or the context deadline
+func (cqlConn *cqlConnStruct) AwaitSchemaAgreement(ctx context.Context) error {
+	if cqlConn.session == nil {
+		err := cqlConn.Ping(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := cqlConn.session.AwaitSchemaAgreement(ctx)
+	if err != nil {
+		cqlConn.checkFatalError(err)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CheckNamedValue converts a named value for the connection.
+// Values the driver can not convert, like maps and slices, are passed to gocql as is.
+func (cqlConn *cqlConnStruct) CheckNamedValue(namedValue *driver.NamedValue) error {
+	return checkNamedValue(namedValue)
+}
+
+// Begin begins a transaction with background context, see BeginTx
 func (cqlConn *cqlConnStruct) Begin() (driver.Tx, error) {
-	return nil, ErrNotSupported
+	return cqlConn.BeginTx(context.Background(), driver.TxOptions{})
 }
 
-// BeginTx not supported
+// BeginTx begins a transaction that adds the insert, update, and delete statements executed in it to a batch.
+// Commit executes the batch, which is atomic but not isolated, and Rollback discards it.
+// Use WithBatchType to set the batch type, the default is LoggedBatch.
+// Queries and other statements return ErrTxQueryNotSupported during the transaction.
+// Read only transactions and isolation levels are not supported.
 func (cqlConn *cqlConnStruct) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
-	return nil, ErrNotSupported
+	if opts.ReadOnly || opts.Isolation != driver.IsolationLevel(0) {
+		return nil, ErrNotSupported
+	}
+	batchType := batchTypeFromContext(ctx)
+	switch batchType {
+	case gocql.LoggedBatch, gocql.UnloggedBatch, gocql.CounterBatch:
+	default:
+		return nil, fmt.Errorf("invalid batch type: %v", batchType)
+	}
+
+	cqlConn.tx = &cqlTxStruct{
+		conn:      cqlConn,
+		context:   ctx,
+		batchType: batchType,
+	}
+	return cqlConn.tx, nil
 }
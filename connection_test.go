@@ -6,6 +6,9 @@ import (
 	"io/ioutil"
 	"log"
 	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
 )
 
 func TestConnectionPing(t *testing.T) {
@@ -84,6 +87,53 @@ func TestConnectionPing(t *testing.T) {
 	}
 }
 
+func TestConnectionPingClosed(t *testing.T) {
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	err := cqlConn.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping error - received: %v - expected: %v ", err, nil)
+	}
+
+	// session closed outside of driver
+	cqlConn.logger = log.New(ioutil.Discard, "", 0)
+	cqlConn.session.Close()
+	err = cqlConn.Ping(context.Background())
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("Ping error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+	if cqlConn.session != nil {
+		t.Fatal("cqlConn.session is not nil")
+	}
+
+	err = cqlConn.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping error - received: %v - expected: %v ", err, nil)
+	}
+
+	// connection closed
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = cqlConn.Ping(context.Background())
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("Ping error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+	if cqlConn.session != nil {
+		t.Fatal("cqlConn.session is not nil")
+	}
+
+	_, err = cqlConn.PrepareContext(context.Background(), "select cql_version from system.local")
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("PrepareContext error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+}
+
 func TestConnectionPingInvalid(t *testing.T) {
 	conn := testGetConnectionHostInvalid(t)
 	if conn == nil {
@@ -179,11 +229,15 @@ func TestConnectionBegin(t *testing.T) {
 	}
 
 	tx, err := conn.Begin()
-	if err == nil || err != ErrNotSupported {
-		t.Fatalf("Begin error - received: %v - expected: %v ", err, ErrNotSupported)
+	if err != nil {
+		t.Fatalf("Begin error - received: %v - expected: %v ", err, nil)
 	}
-	if tx != nil {
-		t.Fatal("tx is not nil")
+	if tx == nil {
+		t.Fatal("tx is nil")
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatalf("Rollback error - received: %v - expected: %v ", err, nil)
 	}
 
 	err = conn.Close()
@@ -199,7 +253,7 @@ func TestConnectionBeginTx(t *testing.T) {
 	}
 	cqlConn := conn.(*cqlConnStruct)
 
-	tx, err := cqlConn.BeginTx(context.Background(), driver.TxOptions{})
+	tx, err := cqlConn.BeginTx(context.Background(), driver.TxOptions{ReadOnly: true})
 	if err == nil || err != ErrNotSupported {
 		t.Fatalf("BeginTx error - received: %v - expected: %v ", err, ErrNotSupported)
 	}
@@ -207,6 +261,113 @@ func TestConnectionBeginTx(t *testing.T) {
 		t.Fatal("tx is not nil")
 	}
 
+	tx, err = cqlConn.BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatalf("BeginTx error - received: %v - expected: %v ", err, nil)
+	}
+	if tx == nil {
+		t.Fatal("tx is nil")
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("Commit error - received: %v - expected: %v ", err, nil)
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestConnectionQueryContext(t *testing.T) {
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := cqlConn.QueryContext(ctx, "select cql_version from system.local", nil)
+	if err != nil {
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, nil)
+	}
+	if rows == nil {
+		t.Fatal("rows is nil")
+	}
+	dest := make([]driver.Value, 1)
+	err = rows.Next(dest)
+	if err != nil {
+		t.Fatalf("Next error - received: %v - expected: %v ", err, nil)
+	}
+	err = rows.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	cancel()
+
+	// named values can not be bound
+	rows, err = cqlConn.QueryContext(context.Background(), "select cql_version from system.local", []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}})
+	if err == nil || err != driver.ErrSkip {
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, driver.ErrSkip)
+	}
+	if rows != nil {
+		t.Fatal("rows is not nil")
+	}
+
+	// canceled context
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	rows, err = cqlConn.QueryContext(ctx, "select cql_version from system.local", nil)
+	if err == nil || err != context.Canceled {
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, context.Canceled)
+	}
+	if rows != nil {
+		t.Fatal("rows is not nil")
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestConnectionExecContext(t *testing.T) {
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := cqlConn.ExecContext(ctx, "select cql_version from system.local", nil)
+	cancel()
+	if err != nil {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, nil)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// named values can not be bound
+	result, err = cqlConn.ExecContext(context.Background(), "select cql_version from system.local", []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}})
+	if err == nil || err != driver.ErrSkip {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, driver.ErrSkip)
+	}
+	if result != nil {
+		t.Fatal("result is not nil")
+	}
+
+	// deadline exceeded
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	result, err = cqlConn.ExecContext(ctx, "select cql_version from system.local", nil)
+	cancel()
+	if err == nil || err != context.DeadlineExceeded {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, context.DeadlineExceeded)
+	}
+	if result != nil {
+		t.Fatal("result is not nil")
+	}
+
 	err = conn.Close()
 	if err != nil {
 		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
@@ -227,3 +388,218 @@ func testGetStatementHostValid(t *testing.T, query string) (driver.Conn, driver.
 	}
 	return conn, stmt
 }
+
+func TestConnectionResetSession(t *testing.T) {
+	conn, err := CqlDriver.Open(TestHostInvalid)
+	if err != nil {
+		t.Fatalf("Open error - received: %v - expected: %v ", err, nil)
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	query := cqlConn.applyOverrides(new(gocql.Query).Consistency(gocql.One))
+	if query.GetConsistency() != gocql.One {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.One)
+	}
+
+	// set override
+	var consistencySetter ConsistencySetter = cqlConn
+	consistencySetter.SetConsistency(gocql.Quorum)
+	query = cqlConn.applyOverrides(new(gocql.Query).Consistency(gocql.One))
+	if query.GetConsistency() != gocql.Quorum {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.Quorum)
+	}
+	batch := cqlConn.applyBatchOverrides(new(gocql.Batch))
+	if batch.GetConsistency() != gocql.Quorum {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", batch.GetConsistency(), gocql.Quorum)
+	}
+
+	// context overrides connection
+	query = applyContext(WithConsistency(context.Background(), gocql.LocalOne), nil, query)
+	if query.GetConsistency() != gocql.LocalOne {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.LocalOne)
+	}
+
+	// reset
+	err = cqlConn.ResetSession(context.Background())
+	if err != nil {
+		t.Fatalf("ResetSession error - received: %v - expected: %v ", err, nil)
+	}
+	query = cqlConn.applyOverrides(new(gocql.Query).Consistency(gocql.One))
+	if query.GetConsistency() != gocql.One {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.One)
+	}
+
+	// closed
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = cqlConn.ResetSession(context.Background())
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("ResetSession error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+}
+
+func TestConnectionResetSessionClosed(t *testing.T) {
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	err := cqlConn.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping error - received: %v - expected: %v ", err, nil)
+	}
+
+	cqlConn.SetConsistency(gocql.All)
+	err = cqlConn.ResetSession(context.Background())
+	if err != nil {
+		t.Fatalf("ResetSession error - received: %v - expected: %v ", err, nil)
+	}
+	if cqlConn.consistency != nil {
+		t.Fatalf("consistency - received: %v - expected: %v ", *cqlConn.consistency, nil)
+	}
+
+	// session closed outside of driver
+	cqlConn.session.Close()
+	err = cqlConn.ResetSession(context.Background())
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("ResetSession error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestConnectionIsValid(t *testing.T) {
+	conn, err := CqlDriver.Open(TestHostInvalid)
+	if err != nil {
+		t.Fatalf("Open error - received: %v - expected: %v ", err, nil)
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	if !cqlConn.IsValid() {
+		t.Fatalf("IsValid - received: %v - expected: %v ", false, true)
+	}
+
+	// not fatal errors
+	cqlConn.checkFatalError(nil)
+	cqlConn.checkFatalError(gocql.ErrNotFound)
+	cqlConn.checkFatalError(context.Canceled)
+	if !cqlConn.IsValid() {
+		t.Fatalf("IsValid - received: %v - expected: %v ", false, true)
+	}
+
+	// simulated fatal error from a statement
+	cqlStmt := &CqlStmt{conn: cqlConn}
+	cqlStmt.conn.checkFatalError(gocql.ErrNoConnections)
+	if cqlConn.IsValid() {
+		t.Fatalf("IsValid - received: %v - expected: %v ", true, false)
+	}
+
+	// statement without connection
+	cqlStmt = &CqlStmt{}
+	cqlStmt.conn.checkFatalError(gocql.ErrNoConnections)
+
+	// closed
+	conn, err = CqlDriver.Open(TestHostInvalid)
+	if err != nil {
+		t.Fatalf("Open error - received: %v - expected: %v ", err, nil)
+	}
+	cqlConn = conn.(*cqlConnStruct)
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	if cqlConn.IsValid() {
+		t.Fatalf("IsValid - received: %v - expected: %v ", true, false)
+	}
+
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: ErrNotSupported, expected: false},
+		{err: gocql.ErrNotFound, expected: false},
+		{err: driver.ErrBadConn, expected: true},
+		{err: gocql.ErrSessionClosed, expected: true},
+		{err: gocql.ErrNoConnections, expected: true},
+		{err: gocql.ErrConnectionClosed, expected: true},
+		{err: gocql.ErrNoConnectionsStarted, expected: true},
+	}
+
+	for _, test := range tests {
+		fatal := isFatalError(test.err)
+		if fatal != test.expected {
+			t.Fatalf("isFatalError failed for: %v - received: %v - expected: %v", test.err, fatal, test.expected)
+		}
+	}
+}
+
+func TestConnectionIsValidSessionClosed(t *testing.T) {
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	cqlConn := conn.(*cqlConnStruct)
+
+	err := cqlConn.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping error - received: %v - expected: %v ", err, nil)
+	}
+	if !cqlConn.IsValid() {
+		t.Fatalf("IsValid - received: %v - expected: %v ", false, true)
+	}
+
+	// session closed outside of driver
+	cqlConn.session.Close()
+	if cqlConn.IsValid() {
+		t.Fatalf("IsValid - received: %v - expected: %v ", true, false)
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestConnectionExecCAS(t *testing.T) {
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	casExecer, ok := conn.(CASExecer)
+	if !ok {
+		t.Fatal("conn is not a CASExecer")
+	}
+
+	// not a lightweight transaction
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, _, err := casExecer.ExecCAS(ctx, "select cql_version from system.local")
+	cancel()
+	if err == nil || err != ErrNotLightweightTransaction {
+		t.Fatalf("ExecCAS error - received: %v - expected: %v ", err, ErrNotLightweightTransaction)
+	}
+
+	// invalid statement
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, _, err = casExecer.ExecCAS(ctx, "select blah from system.local")
+	cancel()
+	if err == nil {
+		t.Fatalf("ExecCAS error - received: %v - expected: %v ", err, "error")
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+
+	_, _, err = casExecer.ExecCAS(context.Background(), "select cql_version from system.local")
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("ExecCAS error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+
+	"github.com/gocql/gocql"
 )
 
 // NewConnector returns a new database connector
@@ -18,6 +20,145 @@ func NewConnector(hosts ...string) driver.Connector {
 	}
 }
 
+// NewConnectorFromClusterConfig returns a new database connector that uses the gocql ClusterConfig as is.
+// Use it for ClusterConfig options that can not be set with a config string.
+// The options are applied in order to the connector and its ClusterConfig.
+func NewConnectorFromClusterConfig(clusterConfig *gocql.ClusterConfig, options ...ConnectorOption) driver.Connector {
+	cqlConnector := &CqlConnector{
+		Logger:        log.New(os.Stderr, "cql ", log.Ldate|log.Ltime|log.LUTC|log.Llongfile),
+		ClusterConfig: clusterConfig,
+	}
+	for _, option := range options {
+		option(cqlConnector)
+	}
+	return cqlConnector
+}
+
+// NewConnectorFromSession returns a new database connector whose connections borrow session instead of creating their own.
+// The caller keeps ownership of session, closing the connections or the sql.DB does not close it.
+// Connections are bad once session is closed.
+func NewConnectorFromSession(session *gocql.Session) driver.Connector {
+	return &CqlConnector{
+		Logger:  log.New(os.Stderr, "cql ", log.Ldate|log.Ltime|log.LUTC|log.Llongfile),
+		session: session,
+	}
+}
+
+// WithQueryObserver sets the gocql QueryObserver, ObserveQuery is called for every query attempt
+// with the statement, start and end time, and error
+func WithQueryObserver(queryObserver gocql.QueryObserver) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.ClusterConfig.QueryObserver = queryObserver
+	}
+}
+
+// WithAuthenticator sets the gocql Authenticator, replacing the PasswordAuthenticator set by username and password.
+// Use it for authenticators like SigV4 for Amazon Keyspaces. The ClusterConfig SslOpts are not changed.
+func WithAuthenticator(authenticator gocql.Authenticator) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.ClusterConfig.Authenticator = authenticator
+	}
+}
+
+// WithDialer sets the gocql Dialer used to connect to hosts, like a SOCKS proxy dialer.
+// Timeout and ConnectTimeout are not changed, ConnectTimeout still limits the connection startup
+// but the dial itself is limited only by the dialer and the context it is given.
+// The config string key connectConcurrency still limits the connections dialed at the same time with dialer.
+func WithDialer(dialer gocql.Dialer) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		if limitDialer, ok := cqlConnector.ClusterConfig.Dialer.(*connectLimitDialer); ok {
+			limitDialer.dialer = dialer
+			return
+		}
+		cqlConnector.ClusterConfig.Dialer = dialer
+	}
+}
+
+// WithHostFilter sets the gocql HostFilter. If the ClusterConfig already has a HostFilter,
+// like from the config string keys dcFilter and hostFilter, hosts have to be accepted by both filters.
+func WithHostFilter(filter gocql.HostFilter) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		existing := cqlConnector.ClusterConfig.HostFilter
+		if existing == nil {
+			cqlConnector.ClusterConfig.HostFilter = filter
+			return
+		}
+		cqlConnector.ClusterConfig.HostFilter = gocql.HostFilterFunc(func(host *gocql.HostInfo) bool {
+			return existing.Accept(host) && filter.Accept(host)
+		})
+	}
+}
+
+// WithConnectObserver sets the gocql ConnectObserver, ObserveConnect is called for every connection attempt
+// with the host, start and end time, and error. It does not change how gocql reconnects.
+func WithConnectObserver(connectObserver gocql.ConnectObserver) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.ClusterConfig.ConnectObserver = connectObserver
+	}
+}
+
+// WithLogger sets the gocql StdLogger used by the sessions of the connector, instead of the package level gocql Logger.
+// The package level gocql Logger is not changed, so other connectors keep logging to it.
+func WithLogger(logger gocql.StdLogger) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.ClusterConfig.Logger = logger
+	}
+}
+
+// WithConvictionPolicy sets the gocql ConvictionPolicy that decides when a host is marked down after a connection error.
+// A nil policy sets gocql SimpleConvictionPolicy, the default, which marks a host down on any connection error.
+func WithConvictionPolicy(policy gocql.ConvictionPolicy) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		if policy == nil {
+			policy = &gocql.SimpleConvictionPolicy{}
+		}
+		cqlConnector.ClusterConfig.ConvictionPolicy = policy
+	}
+}
+
+// WithDisablePrepareCache disables preparing statements without bind markers, like dynamic statements that change every query.
+// Statements with bind markers are still prepared and cached, gocql only sends bind values with prepared statements.
+// DDL statements, like create and alter, are never prepared by gocql.
+func WithDisablePrepareCache(disable bool) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.disablePrepareCache = disable
+	}
+}
+
+// WithDefaultReadConsistency sets the consistency of select statements, instead of the ClusterConfig Consistency.
+// SetConsistency and WithConsistency still override it.
+func WithDefaultReadConsistency(consistency gocql.Consistency) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.readConsistency = &consistency
+	}
+}
+
+// WithDefaultWriteConsistency sets the consistency of insert, update, delete, and begin batch statements, and of BatchExec, instead of the ClusterConfig Consistency.
+// SetConsistency and WithConsistency still override it.
+func WithDefaultWriteConsistency(consistency gocql.Consistency) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.writeConsistency = &consistency
+	}
+}
+
+// WithEagerPoolFill makes Ping wait, up to ConnectTimeout, for the connection pools to fill when it creates a session.
+// gocql makes the first connection to each host while creating the session and the other NumConns - 1 in the background,
+// so without it the first queries can be sent before all connections are made.
+func WithEagerPoolFill(eager bool) ConnectorOption {
+	return func(cqlConnector *CqlConnector) {
+		cqlConnector.eagerPoolFill = eager
+	}
+}
+
+// PrepareCacheSize returns the maximum number of prepared statements gocql caches for each session, ClusterConfig MaxPreparedStmts.
+// Returns 0 when the prepare cache is disabled or there is no ClusterConfig. gocql does not expose the number of cached statements.
+func (cqlConnector *CqlConnector) PrepareCacheSize() int {
+	if cqlConnector.disablePrepareCache || cqlConnector.ClusterConfig == nil {
+		return 0
+	}
+	return cqlConnector.ClusterConfig.MaxPreparedStmts
+}
+
 // Driver returns the cql driver
 func (cqlConnector *CqlConnector) Driver() driver.Driver {
 	return CqlDriver
@@ -29,10 +170,20 @@ func (cqlConnector *CqlConnector) Connect(ctx context.Context) (driver.Conn, err
 		logger:        cqlConnector.Logger,
 		context:       ctx,
 		clusterConfig: cqlConnector.ClusterConfig,
+
+		disablePrepareCache: cqlConnector.disablePrepareCache,
+		readConsistency:     cqlConnector.readConsistency,
+		writeConsistency:    cqlConnector.writeConsistency,
+		eagerPoolFill:       cqlConnector.eagerPoolFill,
 	}
 	if cqlConn.logger == nil {
 		cqlConn.logger = log.New(ioutil.Discard, "", 0)
 	}
+	if cqlConnector.session != nil {
+		cqlConn.session = cqlConnector.session
+		cqlConn.pingQuery = cqlConn.session.Query("select cql_version from system.local")
+		cqlConn.borrowed = true
+	}
 
 	return cqlConn, nil
 }
@@ -4,7 +4,21 @@ package cql
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
 )
 
 func TestConnectorDriver(t *testing.T) {
@@ -67,3 +81,836 @@ func TestConnectorConnect(t *testing.T) {
 		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
 	}
 }
+
+func TestConnectorFromClusterConfig(t *testing.T) {
+	clusterConfig := NewClusterConfig(TestHostValid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutValid
+	clusterConfig.Timeout = TimeoutValid
+	if EnableAuthentication {
+		clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+	}
+
+	connector := NewConnectorFromClusterConfig(clusterConfig)
+	if connector == nil {
+		t.Fatal("connector is nil")
+	}
+	if connector.Driver() != CqlDriver {
+		t.Fatalf("Driver - received: %v - expected: %v ", connector.Driver(), CqlDriver)
+	}
+	cqlConnector := connector.(*CqlConnector)
+	if cqlConnector.ClusterConfig != clusterConfig {
+		t.Fatalf("ClusterConfig - received: %v - expected: %v ", cqlConnector.ClusterConfig, clusterConfig)
+	}
+
+	db := sql.OpenDB(connector)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err := db.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+type testQueryObserver struct {
+	mutex      sync.Mutex
+	statements map[string]int
+}
+
+func (observer *testQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocql.ObservedQuery) {
+	observer.mutex.Lock()
+	observer.statements[observedQuery.Statement]++
+	observer.mutex.Unlock()
+}
+
+func TestConnectorDSNClusterConfigSessions(t *testing.T) {
+	for _, test := range testDSNSessionConfigStrings {
+		dsn, err := ParseDSN(test)
+		if err != nil {
+			t.Fatalf("ParseDSN error - received: %v - expected: %v - configString: %v", err, nil, test)
+		}
+
+		// connections of a connector create their own sessions
+		connector := NewConnectorFromClusterConfig(dsn.ClusterConfig())
+		connector.(*CqlConnector).Logger = log.New(ioutil.Discard, "", 0)
+		for i := 0; i < 2; i++ {
+			conn, err := connector.Connect(context.Background())
+			if err != nil {
+				t.Fatalf("Connect error - received: %v - expected: %v - configString: %v", err, nil, test)
+			}
+			conn.(driver.Pinger).Ping(context.Background())
+			conn.Close()
+		}
+	}
+}
+
+func TestConnectorQueryObserver(t *testing.T) {
+	observer := &testQueryObserver{statements: make(map[string]int)}
+
+	clusterConfig := NewClusterConfig(TestHostValid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutValid
+	clusterConfig.Timeout = TimeoutValid
+	if EnableAuthentication {
+		clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+	}
+
+	connector := NewConnectorFromClusterConfig(clusterConfig, WithQueryObserver(observer))
+	if clusterConfig.QueryObserver != observer {
+		t.Fatalf("QueryObserver - received: %v - expected: %v ", clusterConfig.QueryObserver, observer)
+	}
+
+	db := sql.OpenDB(connector)
+
+	queryStatement := "select cluster_name from system.local"
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := db.QueryContext(ctx, queryStatement)
+	if err != nil {
+		cancel()
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, nil)
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+
+	execStatement := "select release_version from system.local"
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, execStatement)
+	cancel()
+	if err != nil {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	observer.mutex.Lock()
+	queryCount := observer.statements[queryStatement]
+	execCount := observer.statements[execStatement]
+	observer.mutex.Unlock()
+	if queryCount != 1 {
+		t.Fatalf("QueryContext observed - received: %v - expected: %v ", queryCount, 1)
+	}
+	if execCount != 1 {
+		t.Fatalf("ExecContext observed - received: %v - expected: %v ", execCount, 1)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+type testConnectObserver struct {
+	mutex    sync.Mutex
+	connects []gocql.ObservedConnect
+}
+
+func (observer *testConnectObserver) ObserveConnect(observedConnect gocql.ObservedConnect) {
+	observer.mutex.Lock()
+	observer.connects = append(observer.connects, observedConnect)
+	observer.mutex.Unlock()
+}
+
+func TestConnectorConnectObserver(t *testing.T) {
+	observer := &testConnectObserver{}
+
+	clusterConfig := NewClusterConfig(TestHostInvalid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutInvalid
+
+	connector := NewConnectorFromClusterConfig(clusterConfig, WithConnectObserver(observer))
+	if clusterConfig.ConnectObserver != observer {
+		t.Fatalf("ConnectObserver - received: %v - expected: %v ", clusterConfig.ConnectObserver, observer)
+	}
+	connector.(*CqlConnector).Logger = nil
+
+	db := sql.OpenDB(connector)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err := db.PingContext(ctx)
+	cancel()
+	if err == nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, "error")
+	}
+
+	observer.mutex.Lock()
+	connects := observer.connects
+	observer.connects = nil
+	observer.mutex.Unlock()
+	if len(connects) < 1 {
+		t.Fatalf("connects - received: %v - expected: %v ", len(connects), "at least 1")
+	}
+	for _, connect := range connects {
+		if connect.Err == nil {
+			t.Fatalf("connect Err - received: %v - expected: %v ", connect.Err, "error")
+		}
+		if connect.Host == nil || connect.Host.ConnectAddress().String() != TestHostInvalid {
+			t.Fatalf("connect Host - received: %v - expected: %v ", connect.Host, TestHostInvalid)
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+
+	clusterConfig = NewClusterConfig(TestHostValid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutValid
+	clusterConfig.Timeout = TimeoutValid
+	if EnableAuthentication {
+		clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+	}
+
+	db = sql.OpenDB(NewConnectorFromClusterConfig(clusterConfig, WithConnectObserver(observer)))
+
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	observer.mutex.Lock()
+	connects = observer.connects
+	observer.mutex.Unlock()
+	if len(connects) < 1 {
+		t.Fatalf("connects - received: %v - expected: %v ", len(connects), "at least 1")
+	}
+	if connects[0].Err != nil {
+		t.Fatalf("connect Err - received: %v - expected: %v ", connects[0].Err, nil)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestConnectorEagerPoolFill(t *testing.T) {
+	testObserver := &testConnectObserver{}
+	observer := &poolFillObserver{observer: testObserver, hosts: make(map[string]struct{}), changed: make(chan struct{}, 1)}
+	if observer.filled(2, 1) {
+		t.Fatalf("filled - received: %v - expected: %v ", true, false)
+	}
+	host := &gocql.HostInfo{}
+	observer.ObserveConnect(gocql.ObservedConnect{Host: host})
+	observer.ObserveConnect(gocql.ObservedConnect{Host: host, Err: fmt.Errorf("connect error")})
+	observer.ObserveConnect(gocql.ObservedConnect{Host: host})
+	if observer.filled(2, 1) {
+		t.Fatalf("filled - received: %v - expected: %v ", true, false)
+	}
+	observer.ObserveConnect(gocql.ObservedConnect{Host: host})
+	if !observer.filled(2, 1) {
+		t.Fatalf("filled - received: %v - expected: %v ", false, true)
+	}
+	testObserver.mutex.Lock()
+	connects := len(testObserver.connects)
+	testObserver.mutex.Unlock()
+	if connects != 4 {
+		t.Fatalf("connects - received: %v - expected: %v ", connects, 4)
+	}
+
+	testObserver = &testConnectObserver{}
+	clusterConfig := NewClusterConfig(TestHostValid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutValid
+	clusterConfig.Timeout = TimeoutValid
+	clusterConfig.NumConns = 3
+	clusterConfig.ProtoVersion = 4
+	if EnableAuthentication {
+		clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+	}
+
+	db := sql.OpenDB(NewConnectorFromClusterConfig(clusterConfig, WithConnectObserver(testObserver), WithEagerPoolFill(true)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err := db.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	// the control connection and NumConns pool connections
+	testObserver.mutex.Lock()
+	connected := 0
+	for _, connect := range testObserver.connects {
+		if connect.Err == nil {
+			connected++
+		}
+	}
+	testObserver.mutex.Unlock()
+	if connected < 4 {
+		t.Fatalf("connected - received: %v - expected: %v ", connected, "at least 4")
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+type testAuthenticator struct {
+	mutex         sync.Mutex
+	authenticator gocql.Authenticator
+	challenges    int
+}
+
+func (authenticator *testAuthenticator) Challenge(req []byte) ([]byte, gocql.Authenticator, error) {
+	authenticator.mutex.Lock()
+	authenticator.challenges++
+	authenticator.mutex.Unlock()
+	return authenticator.authenticator.Challenge(req)
+}
+
+func (authenticator *testAuthenticator) Success(data []byte) error {
+	return authenticator.authenticator.Success(data)
+}
+
+func TestConnectorAuthenticator(t *testing.T) {
+	clusterConfig, err := ConfigStringToClusterConfig("127.0.0.1?username=user&password=pass&sslInsecureSkipVerify=true&sslMinVersion=1.2")
+	if err != nil {
+		t.Fatalf("ConfigStringToClusterConfig error - received: %v - expected: %v ", err, nil)
+	}
+	sslOpts := clusterConfig.SslOpts
+
+	authenticator := &testAuthenticator{authenticator: gocql.PasswordAuthenticator{Username: Username, Password: Password}}
+	NewConnectorFromClusterConfig(clusterConfig, WithAuthenticator(authenticator))
+	if clusterConfig.Authenticator != authenticator {
+		t.Fatalf("Authenticator - received: %v - expected: %v ", clusterConfig.Authenticator, authenticator)
+	}
+	if clusterConfig.SslOpts != sslOpts || !clusterConfig.SslOpts.Config.InsecureSkipVerify || clusterConfig.SslOpts.Config.MinVersion != tls.VersionTLS12 {
+		t.Fatalf("SslOpts - received: %v - expected: %v ", clusterConfig.SslOpts, sslOpts)
+	}
+
+	if !EnableAuthentication {
+		return
+	}
+
+	clusterConfig = NewClusterConfig(TestHostValid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutValid
+	clusterConfig.Timeout = TimeoutValid
+
+	db := sql.OpenDB(NewConnectorFromClusterConfig(clusterConfig, WithAuthenticator(authenticator)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	authenticator.mutex.Lock()
+	challenges := authenticator.challenges
+	authenticator.mutex.Unlock()
+	if challenges < 1 {
+		t.Fatalf("challenges - received: %v - expected: %v ", challenges, "at least 1")
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+type testDialer struct {
+	mutex     sync.Mutex
+	addresses []string
+}
+
+func (dialer *testDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer.mutex.Lock()
+	dialer.addresses = append(dialer.addresses, address)
+	dialer.mutex.Unlock()
+	return nil, fmt.Errorf("test dialer")
+}
+
+func TestConnectorDialer(t *testing.T) {
+	dialer := &testDialer{}
+
+	clusterConfig, err := ConfigStringToClusterConfig("192.0.2.1?timeout=2s&connectTimeout=3s")
+	if err != nil {
+		t.Fatalf("ConfigStringToClusterConfig error - received: %v - expected: %v ", err, nil)
+	}
+
+	connector := NewConnectorFromClusterConfig(clusterConfig, WithDialer(dialer))
+	if clusterConfig.Dialer != dialer {
+		t.Fatalf("Dialer - received: %v - expected: %v ", clusterConfig.Dialer, dialer)
+	}
+	if clusterConfig.Timeout != 2*time.Second || clusterConfig.ConnectTimeout != 3*time.Second {
+		t.Fatalf("Timeout ConnectTimeout - received: %v %v - expected: %v %v ", clusterConfig.Timeout, clusterConfig.ConnectTimeout, 2*time.Second, 3*time.Second)
+	}
+	connector.(*CqlConnector).Logger = nil
+
+	db := sql.OpenDB(connector)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.PingContext(ctx)
+	cancel()
+	if err == nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, "error")
+	}
+
+	dialer.mutex.Lock()
+	addresses := dialer.addresses
+	dialer.mutex.Unlock()
+	if len(addresses) < 1 {
+		t.Fatalf("Dial calls - received: %v - expected: %v ", len(addresses), "at least 1")
+	}
+	for _, address := range addresses {
+		if address != "192.0.2.1:9042" {
+			t.Fatalf("Dial address - received: %v - expected: %v ", address, "192.0.2.1:9042")
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+type testLogger struct {
+	mutex sync.Mutex
+	lines []string
+}
+
+func (logger *testLogger) Print(v ...interface{}) {
+	logger.mutex.Lock()
+	logger.lines = append(logger.lines, fmt.Sprint(v...))
+	logger.mutex.Unlock()
+}
+
+func (logger *testLogger) Printf(format string, v ...interface{}) {
+	logger.mutex.Lock()
+	logger.lines = append(logger.lines, fmt.Sprintf(format, v...))
+	logger.mutex.Unlock()
+}
+
+func (logger *testLogger) Println(v ...interface{}) {
+	logger.mutex.Lock()
+	logger.lines = append(logger.lines, fmt.Sprintln(v...))
+	logger.mutex.Unlock()
+}
+
+func TestConnectorLogger(t *testing.T) {
+	logger := &testLogger{}
+	globalLogger := gocql.Logger
+
+	// protoVersion is set so gocql dials the control connection, which logs the dial error
+	clusterConfig, err := ConfigStringToClusterConfig("192.0.2.1?timeout=2s&connectTimeout=2s&protoVersion=4")
+	if err != nil {
+		t.Fatalf("ConfigStringToClusterConfig error - received: %v - expected: %v ", err, nil)
+	}
+
+	connector := NewConnectorFromClusterConfig(clusterConfig, WithDialer(&testDialer{}), WithLogger(logger))
+	if clusterConfig.Logger != logger {
+		t.Fatalf("Logger - received: %v - expected: %v ", clusterConfig.Logger, logger)
+	}
+	connector.(*CqlConnector).Logger = nil
+
+	db := sql.OpenDB(connector)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.PingContext(ctx)
+	cancel()
+	if err == nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, "error")
+	}
+
+	logger.mutex.Lock()
+	lines := strings.Join(logger.lines, "")
+	logger.mutex.Unlock()
+	if !strings.Contains(lines, "test dialer") {
+		t.Fatalf("Logger lines - received: %v - expected: %v ", lines, "test dialer")
+	}
+	if gocql.Logger != globalLogger {
+		t.Fatalf("gocql Logger - received: %v - expected: %v ", gocql.Logger, globalLogger)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+type testConvictionPolicy struct {
+	errors int
+}
+
+func (policy *testConvictionPolicy) AddFailure(err error, host *gocql.HostInfo) bool {
+	policy.errors++
+	return policy.errors > 2
+}
+
+func (policy *testConvictionPolicy) Reset(host *gocql.HostInfo) {
+	policy.errors = 0
+}
+
+func TestConnectorConvictionPolicy(t *testing.T) {
+	policy := &testConvictionPolicy{}
+	clusterConfig := NewClusterConfig()
+
+	NewConnectorFromClusterConfig(clusterConfig, WithConvictionPolicy(policy))
+	if clusterConfig.ConvictionPolicy != policy {
+		t.Fatalf("ConvictionPolicy - received: %v - expected: %v ", clusterConfig.ConvictionPolicy, policy)
+	}
+
+	NewConnectorFromClusterConfig(clusterConfig, WithConvictionPolicy(nil))
+	if _, ok := clusterConfig.ConvictionPolicy.(*gocql.SimpleConvictionPolicy); !ok {
+		t.Fatalf("ConvictionPolicy - received: %T - expected: %v ", clusterConfig.ConvictionPolicy, "*gocql.SimpleConvictionPolicy")
+	}
+	if !reflect.DeepEqual(clusterConfig.ConvictionPolicy, NewClusterConfig().ConvictionPolicy) {
+		t.Fatalf("ConvictionPolicy - received: %#v - expected: %#v ", clusterConfig.ConvictionPolicy, NewClusterConfig().ConvictionPolicy)
+	}
+}
+
+// testConcurrencyDialer records the most connections dialed at the same time
+type testConcurrencyDialer struct {
+	mutex   sync.Mutex
+	dialing int
+	most    int
+}
+
+func (dialer *testConcurrencyDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer.mutex.Lock()
+	dialer.dialing++
+	if dialer.dialing > dialer.most {
+		dialer.most = dialer.dialing
+	}
+	dialer.mutex.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	dialer.mutex.Lock()
+	dialer.dialing--
+	dialer.mutex.Unlock()
+	return nil, fmt.Errorf("test dialer")
+}
+
+func TestConnectorConnectConcurrency(t *testing.T) {
+	clusterConfig, err := ConfigStringToClusterConfig("127.0.0.1?connectConcurrency=2")
+	if err != nil {
+		t.Fatalf("ConfigStringToClusterConfig error - received: %v - expected: %v ", err, nil)
+	}
+
+	dialer := &testConcurrencyDialer{}
+	NewConnectorFromClusterConfig(clusterConfig, WithDialer(dialer))
+	limitDialer, ok := clusterConfig.Dialer.(*connectLimitDialer)
+	if !ok || limitDialer.dialer != dialer {
+		t.Fatalf("Dialer - received: %v - expected: %v ", clusterConfig.Dialer, dialer)
+	}
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			clusterConfig.Dialer.DialContext(context.Background(), "tcp", "127.0.0.1:9042")
+		}()
+	}
+	waitGroup.Wait()
+
+	dialer.mutex.Lock()
+	most := dialer.most
+	dialer.mutex.Unlock()
+	if most != 2 {
+		t.Fatalf("most dialing - received: %v - expected: %v ", most, 2)
+	}
+
+	// waiting dial is canceled by the context
+	limitDialer.semaphore <- struct{}{}
+	limitDialer.semaphore <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	_, err = clusterConfig.Dialer.DialContext(ctx, "tcp", "127.0.0.1:9042")
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Fatalf("DialContext error - received: %v - expected: %v ", err, context.DeadlineExceeded)
+	}
+}
+
+// testPrepareDialer records the statements of the prepare frames written to its connections
+type testPrepareDialer struct {
+	mutex      sync.Mutex
+	statements map[string]int
+}
+
+type testPrepareConn struct {
+	net.Conn
+	dialer *testPrepareDialer
+	buffer []byte
+}
+
+func (dialer *testPrepareDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &testPrepareConn{Conn: conn, dialer: dialer}, nil
+}
+
+func (conn *testPrepareConn) Write(data []byte) (int, error) {
+	// frame header is version, flags, stream, opcode, and body length, prepare opcode is 0x09
+	conn.buffer = append(conn.buffer, data...)
+	for len(conn.buffer) >= 9 {
+		length := 9 + int(binary.BigEndian.Uint32(conn.buffer[5:9]))
+		if len(conn.buffer) < length {
+			break
+		}
+		if conn.buffer[4] == 0x09 && length >= 13 {
+			end := 13 + int(binary.BigEndian.Uint32(conn.buffer[9:13]))
+			if end <= length {
+				conn.dialer.mutex.Lock()
+				conn.dialer.statements[string(conn.buffer[13:end])]++
+				conn.dialer.mutex.Unlock()
+			}
+		}
+		conn.buffer = conn.buffer[length:]
+	}
+	return conn.Conn.Write(data)
+}
+
+func TestConnectorPrepareCacheSize(t *testing.T) {
+	clusterConfig := NewClusterConfig()
+	clusterConfig.MaxPreparedStmts = 100
+
+	connector := NewConnectorFromClusterConfig(clusterConfig)
+	size := connector.(*CqlConnector).PrepareCacheSize()
+	if size != 100 {
+		t.Fatalf("PrepareCacheSize - received: %v - expected: %v ", size, 100)
+	}
+
+	connector = NewConnectorFromClusterConfig(clusterConfig, WithDisablePrepareCache(true))
+	size = connector.(*CqlConnector).PrepareCacheSize()
+	if size != 0 {
+		t.Fatalf("PrepareCacheSize - received: %v - expected: %v ", size, 0)
+	}
+
+	connector = NewConnectorFromClusterConfig(clusterConfig, WithDisablePrepareCache(true), WithDisablePrepareCache(false))
+	size = connector.(*CqlConnector).PrepareCacheSize()
+	if size != 100 {
+		t.Fatalf("PrepareCacheSize - received: %v - expected: %v ", size, 100)
+	}
+}
+
+func TestConnectorDisablePrepareCache(t *testing.T) {
+	tests := []struct {
+		disable   bool
+		statement string
+		args      []interface{}
+		prepared  string
+	}{
+		{disable: false, statement: "select cluster_name from system.local", prepared: "select cluster_name from system.local"},
+		{disable: true, statement: "select cluster_name from system.local"},
+		{disable: true, statement: "select cluster_name from system.local where key = ?", args: []interface{}{"local"}, prepared: "select cluster_name from system.local where key = ?"},
+	}
+
+	for _, test := range tests {
+		dialer := &testPrepareDialer{statements: make(map[string]int)}
+
+		clusterConfig := NewClusterConfig(TestHostValid)
+		clusterConfig.ConnectTimeout = ConnectTimeoutValid
+		clusterConfig.Timeout = TimeoutValid
+		if EnableAuthentication {
+			clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+		}
+
+		db := sql.OpenDB(NewConnectorFromClusterConfig(clusterConfig, WithDialer(dialer), WithDisablePrepareCache(test.disable)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		rows, err := db.QueryContext(ctx, test.statement, test.args...)
+		if err != nil {
+			cancel()
+			t.Fatalf("QueryContext error - received: %v - expected: %v ", err, nil)
+		}
+		err = rows.Close()
+		cancel()
+		if err != nil {
+			t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+		}
+
+		dialer.mutex.Lock()
+		statements := dialer.statements
+		dialer.mutex.Unlock()
+		if test.prepared == "" {
+			for statement := range statements {
+				if strings.Contains(statement, test.statement) {
+					t.Fatalf("prepared - received: %v - expected: %v - disable: %v", statement, "not prepared", test.disable)
+				}
+			}
+		} else if statements[test.prepared] < 1 {
+			t.Fatalf("prepared - received: %v - expected: %v - disable: %v", statements, test.prepared, test.disable)
+		}
+
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+		}
+	}
+}
+
+func TestConnectorDefaultConsistency(t *testing.T) {
+	tests := []struct {
+		options   []ConnectorOption
+		statement string
+		expected  gocql.Consistency
+	}{
+		{statement: "select * from a", expected: gocql.Quorum},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne)}, statement: "select * from a", expected: gocql.LocalOne},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne)}, statement: "insert into a (b) values (1)", expected: gocql.Quorum},
+		{options: []ConnectorOption{WithDefaultWriteConsistency(gocql.All)}, statement: "select * from a", expected: gocql.Quorum},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All)}, statement: "/* unprepared */ select * from a", expected: gocql.LocalOne},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All)}, statement: "insert into a (b) values (1)", expected: gocql.All},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All)}, statement: "update a set b = 1", expected: gocql.All},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All)}, statement: "delete from a", expected: gocql.All},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All)}, statement: "begin batch delete from a apply batch", expected: gocql.All},
+		{options: []ConnectorOption{WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All)}, statement: "create table a (b int primary key)", expected: gocql.Quorum},
+	}
+
+	for _, test := range tests {
+		connector := NewConnectorFromClusterConfig(NewClusterConfig(), test.options...)
+		conn, err := connector.Connect(context.Background())
+		if err != nil {
+			t.Fatalf("Connect error - received: %v - expected: %v ", err, nil)
+		}
+		cqlConn := conn.(*cqlConnStruct)
+
+		query := cqlConn.applyOverrides((&gocql.Session{}).Query(test.statement).Consistency(gocql.Quorum))
+		if query.GetConsistency() != test.expected {
+			t.Fatalf("GetConsistency failed for: %v - received: %v - expected: %v", test.statement, query.GetConsistency(), test.expected)
+		}
+
+		// SetConsistency overrides the default consistency
+		cqlConn.SetConsistency(gocql.Two)
+		query = cqlConn.applyOverrides((&gocql.Session{}).Query(test.statement).Consistency(gocql.Quorum))
+		if query.GetConsistency() != gocql.Two {
+			t.Fatalf("GetConsistency failed for: %v - received: %v - expected: %v", test.statement, query.GetConsistency(), gocql.Two)
+		}
+	}
+
+	connector := NewConnectorFromClusterConfig(NewClusterConfig(), WithDefaultReadConsistency(gocql.LocalOne), WithDefaultWriteConsistency(gocql.All))
+	conn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("Connect error - received: %v - expected: %v ", err, nil)
+	}
+	batch := conn.(*cqlConnStruct).applyBatchOverrides(new(gocql.Batch))
+	if batch.GetConsistency() != gocql.All {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", batch.GetConsistency(), gocql.All)
+	}
+}
+
+func TestConnectorHostFilter(t *testing.T) {
+	testHost := func(address string, hostID string) *gocql.HostInfo {
+		host := &gocql.HostInfo{}
+		host.SetConnectAddress(net.ParseIP(address))
+		host.SetHostID(hostID)
+		return host
+	}
+	filter := gocql.HostFilterFunc(func(host *gocql.HostInfo) bool {
+		return host.HostID() == "a"
+	})
+
+	tests := []struct {
+		info         string
+		configString string
+		host         *gocql.HostInfo
+		expected     bool
+	}{
+		{info: "filter accept", configString: "", host: testHost("10.0.0.1", "a"), expected: true},
+		{info: "filter reject", configString: "", host: testHost("10.0.0.1", "b"), expected: false},
+		{info: "whitelist and filter accept", configString: "?hostFilter=whitelist:10.0.0.1,10.0.0.2", host: testHost("10.0.0.2", "a"), expected: true},
+		{info: "whitelist accept filter reject", configString: "?hostFilter=whitelist:10.0.0.1,10.0.0.2", host: testHost("10.0.0.1", "b"), expected: false},
+		{info: "whitelist reject filter accept", configString: "?hostFilter=whitelist:10.0.0.1,10.0.0.2", host: testHost("10.0.0.3", "a"), expected: false},
+		{info: "dcFilter reject filter accept", configString: "?dcFilter=dc1", host: testHost("10.0.0.1", "a"), expected: false},
+	}
+
+	for _, test := range tests {
+		clusterConfig, err := ConfigStringToClusterConfig(test.configString)
+		if err != nil {
+			t.Fatalf("ConfigStringToClusterConfig error - received: %v - expected: %v - info: %v", err, nil, test.info)
+		}
+		NewConnectorFromClusterConfig(clusterConfig, WithHostFilter(filter))
+		if clusterConfig.HostFilter == nil {
+			t.Fatalf("HostFilter is nil - info: %v", test.info)
+		}
+		accept := clusterConfig.HostFilter.Accept(test.host)
+		if accept != test.expected {
+			t.Fatalf("Accept - received: %v - expected: %v - info: %v", accept, test.expected, test.info)
+		}
+	}
+}
+
+func TestConnectorFromSession(t *testing.T) {
+	clusterConfig := NewClusterConfig(TestHostValid)
+	clusterConfig.ConnectTimeout = ConnectTimeoutValid
+	clusterConfig.Timeout = TimeoutValid
+	if EnableAuthentication {
+		clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+	}
+	session, err := clusterConfig.CreateSession()
+	if err != nil {
+		t.Fatalf("CreateSession error - received: %v - expected: %v ", err, nil)
+	}
+
+	connector := NewConnectorFromSession(session)
+	if connector == nil {
+		t.Fatal("connector is nil")
+	}
+	if connector.Driver() != CqlDriver {
+		t.Fatalf("Driver - received: %v - expected: %v ", connector.Driver(), CqlDriver)
+	}
+
+	db1 := sql.OpenDB(connector)
+	db2 := sql.OpenDB(connector)
+
+	for _, db := range []*sql.DB{db1, db2} {
+		var releaseVersion string
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		err = db.QueryRowContext(ctx, "select release_version from system.local").Scan(&releaseVersion)
+		cancel()
+		if err != nil {
+			t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+		}
+		if releaseVersion == "" {
+			t.Fatal("releaseVersion is empty")
+		}
+	}
+
+	// closing one db does not close the session for the other db
+	err = db1.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	if session.Closed() {
+		t.Fatal("session is closed")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db2.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	err = db2.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	if session.Closed() {
+		t.Fatal("session is closed")
+	}
+
+	// connections are bad after the session is closed
+	session.Close()
+	db3 := sql.OpenDB(connector)
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db3.PingContext(ctx)
+	cancel()
+	if err == nil || err != driver.ErrBadConn {
+		t.Fatalf("PingContext error - received: %v - expected: %v ", err, driver.ErrBadConn)
+	}
+
+	err = db3.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
@@ -0,0 +1,500 @@
+package cql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+type (
+	contextKey int
+
+	// warner returns the warnings of a query, like gocql Iter
+	warner interface {
+		Warnings() []string
+	}
+
+	// customPayloader returns the response custom payload of a query, like gocql Iter
+	customPayloader interface {
+		GetCustomPayload() map[string][]byte
+	}
+
+	// attempter returns the attempts and average attempt latency in nanoseconds of a query, like gocql Query
+	attempter interface {
+		Attempts() int
+		Latency() int64
+	}
+
+	// hoster returns the host a query was sent to, like gocql Iter
+	hoster interface {
+		Host() *gocql.HostInfo
+	}
+
+	// rowScanner scans the first row of a query, like gocql Query
+	rowScanner interface {
+		Scan(dest ...interface{}) error
+	}
+
+	// pageStateValue is the context value set by WithPageState
+	pageStateValue struct {
+		pageState     []byte
+		nextPageState *[]byte
+	}
+)
+
+const (
+	contextKeyConsistency contextKey = iota
+	contextKeyTracer
+	contextKeyPageSize
+	contextKeyPageState
+	contextKeyIdempotent
+	contextKeySpeculativeExecution
+	contextKeyTimestamp
+	contextKeySerialConsistency
+	contextKeyWarnings
+	contextKeyCustomPayload
+	contextKeyResponseCustomPayload
+	contextKeyRetryPolicy
+	contextKeyQueryStats
+	contextKeyWithoutPaging
+	contextKeyBatchType
+	contextKeySchemaVersion
+)
+
+// schemaVersionStatement selects the schema version of the node the query is sent to
+const schemaVersionStatement = "select schema_version from system.local where key = 'local'"
+
+const (
+	// traceSessionStatement selects the duration of a trace session, null until the trace is finished
+	traceSessionStatement = "select duration from system_traces.sessions where session_id = ?"
+	// traceEventsStatement selects the events of a trace session
+	traceEventsStatement = "select event_id, activity, source, source_elapsed, thread from system_traces.events where session_id = ?"
+	// traceWaits is the number of times to wait traceWait for a trace session to finish
+	traceWaits = 10
+	// traceWait is the time to wait for a trace session to finish between selecting it
+	traceWait = 100 * time.Millisecond
+)
+
+// WithConsistency returns a copy of ctx that sets the consistency of queries executed with it,
+// overriding the cluster consistency
+func WithConsistency(ctx context.Context, consistency gocql.Consistency) context.Context {
+	return context.WithValue(ctx, contextKeyConsistency, consistency)
+}
+
+// consistencyFromContext returns the consistency set by WithConsistency
+func consistencyFromContext(ctx context.Context) (gocql.Consistency, bool) {
+	consistency, ok := ctx.Value(contextKeyConsistency).(gocql.Consistency)
+	return consistency, ok
+}
+
+// WithTracing returns a copy of ctx that traces queries executed with it using tracer.
+// A SliceTracer records the trace events of the queries.
+func WithTracing(ctx context.Context, tracer gocql.Tracer) context.Context {
+	return context.WithValue(ctx, contextKeyTracer, tracer)
+}
+
+// tracerFromContext returns the tracer set by WithTracing
+func tracerFromContext(ctx context.Context) (gocql.Tracer, bool) {
+	tracer, ok := ctx.Value(contextKeyTracer).(gocql.Tracer)
+	return tracer, ok && tracer != nil
+}
+
+// WithPageSize returns a copy of ctx that sets the page size of queries executed with it,
+// overriding the cluster page size
+func WithPageSize(ctx context.Context, pageSize int) context.Context {
+	return context.WithValue(ctx, contextKeyPageSize, pageSize)
+}
+
+// pageSizeFromContext returns the page size set by WithPageSize
+func pageSizeFromContext(ctx context.Context) (int, bool) {
+	pageSize, ok := ctx.Value(contextKeyPageSize).(int)
+	return pageSize, ok
+}
+
+// WithoutPaging returns a copy of ctx that disables paging of queries executed with it,
+// all rows are returned in a single page. It can not be used with WithPageSize.
+func WithoutPaging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyWithoutPaging, true)
+}
+
+// withoutPagingFromContext returns true if paging is disabled by WithoutPaging
+func withoutPagingFromContext(ctx context.Context) bool {
+	withoutPaging, _ := ctx.Value(contextKeyWithoutPaging).(bool)
+	return withoutPaging
+}
+
+// WithPageState returns a copy of ctx that queries one page at a time, starting at pageState.
+// Use a nil pageState for the first page. When the query is executed nextPageState is set to the page state
+// of the next page, which is empty when there are no more pages.
+// Use WithPageSize to set the number of rows in a page.
+func WithPageState(ctx context.Context, pageState []byte, nextPageState *[]byte) context.Context {
+	return context.WithValue(ctx, contextKeyPageState, pageStateValue{pageState: pageState, nextPageState: nextPageState})
+}
+
+// pageStateFromContext returns the page state set by WithPageState
+func pageStateFromContext(ctx context.Context) (pageStateValue, bool) {
+	pageState, ok := ctx.Value(contextKeyPageState).(pageStateValue)
+	return pageState, ok
+}
+
+// setNextPageState sets the next page state set by WithPageState to the page state of the iter
+func setNextPageState(ctx context.Context, iter *gocql.Iter) {
+	pageState, ok := pageStateFromContext(ctx)
+	if !ok || pageState.nextPageState == nil {
+		return
+	}
+	*pageState.nextPageState = iter.PageState()
+}
+
+// WithIdempotent returns a copy of ctx that marks queries executed with it as idempotent or not,
+// overriding the cluster default idempotence. Only idempotent queries are retried or speculatively executed.
+func WithIdempotent(ctx context.Context, idempotent bool) context.Context {
+	return context.WithValue(ctx, contextKeyIdempotent, idempotent)
+}
+
+// idempotentFromContext returns the idempotence set by WithIdempotent
+func idempotentFromContext(ctx context.Context) (bool, bool) {
+	idempotent, ok := ctx.Value(contextKeyIdempotent).(bool)
+	return idempotent, ok
+}
+
+// WithSpeculativeExecution returns a copy of ctx that sets the speculative execution policy of queries executed with it.
+// gocql only speculatively executes idempotent queries, see WithIdempotent.
+func WithSpeculativeExecution(ctx context.Context, policy gocql.SpeculativeExecutionPolicy) context.Context {
+	return context.WithValue(ctx, contextKeySpeculativeExecution, policy)
+}
+
+// speculativeExecutionFromContext returns the speculative execution policy set by WithSpeculativeExecution
+func speculativeExecutionFromContext(ctx context.Context) (gocql.SpeculativeExecutionPolicy, bool) {
+	policy, ok := ctx.Value(contextKeySpeculativeExecution).(gocql.SpeculativeExecutionPolicy)
+	return policy, ok && policy != nil
+}
+
+// NewSimpleSpeculativeExecution returns a gocql SimpleSpeculativeExecution policy that makes up to numAttempts
+// additional executions, each one timeoutDelay after the previous one
+func NewSimpleSpeculativeExecution(numAttempts int, timeoutDelay time.Duration) *gocql.SimpleSpeculativeExecution {
+	return &gocql.SimpleSpeculativeExecution{
+		NumAttempts:  numAttempts,
+		TimeoutDelay: timeoutDelay,
+	}
+}
+
+// WithTimestamp returns a copy of ctx that sets the timestamp, in microseconds since the epoch,
+// of queries executed with it, overriding the cluster default timestamp
+func WithTimestamp(ctx context.Context, timestamp int64) context.Context {
+	return context.WithValue(ctx, contextKeyTimestamp, timestamp)
+}
+
+// timestampFromContext returns the timestamp set by WithTimestamp
+func timestampFromContext(ctx context.Context) (int64, bool) {
+	timestamp, ok := ctx.Value(contextKeyTimestamp).(int64)
+	return timestamp, ok
+}
+
+// WithSerialConsistency returns a copy of ctx that sets the serial consistency of conditional queries executed with it,
+// overriding the cluster serial consistency
+func WithSerialConsistency(ctx context.Context, serialConsistency gocql.SerialConsistency) context.Context {
+	return context.WithValue(ctx, contextKeySerialConsistency, serialConsistency)
+}
+
+// serialConsistencyFromContext returns the serial consistency set by WithSerialConsistency
+func serialConsistencyFromContext(ctx context.Context) (gocql.SerialConsistency, bool) {
+	serialConsistency, ok := ctx.Value(contextKeySerialConsistency).(gocql.SerialConsistency)
+	return serialConsistency, ok
+}
+
+// WithRetryPolicy returns a copy of ctx that sets the retry policy of queries and batches executed with it,
+// overriding the cluster retry policy
+func WithRetryPolicy(ctx context.Context, policy gocql.RetryPolicy) context.Context {
+	return context.WithValue(ctx, contextKeyRetryPolicy, policy)
+}
+
+// retryPolicyFromContext returns the retry policy set by WithRetryPolicy
+func retryPolicyFromContext(ctx context.Context) (gocql.RetryPolicy, bool) {
+	policy, ok := ctx.Value(contextKeyRetryPolicy).(gocql.RetryPolicy)
+	return policy, ok
+}
+
+// WithQueryStats returns a copy of ctx that sets stats to the host, attempts, and latency of a query executed with it.
+// For queries that return rows they are the stats of the first page. Batches do not set stats.
+func WithQueryStats(ctx context.Context, stats *QueryStats) context.Context {
+	return context.WithValue(ctx, contextKeyQueryStats, stats)
+}
+
+// setQueryStats sets the stats set by WithQueryStats to the stats of the query and the host of iter
+func setQueryStats(ctx context.Context, query attempter, iter hoster) {
+	stats, ok := ctx.Value(contextKeyQueryStats).(*QueryStats)
+	if !ok || stats == nil {
+		return
+	}
+	*stats = QueryStats{
+		Host:     iter.Host(),
+		Attempts: query.Attempts(),
+		Latency:  time.Duration(query.Latency()),
+	}
+}
+
+// WithWarnings returns a copy of ctx that sets warnings to the warnings returned by the server,
+// like reading too many tombstones, when a query is executed with it.
+// For queries that return rows they are the warnings of the first page.
+// gocql does not return the warnings of batches.
+func WithWarnings(ctx context.Context, warnings *[]string) context.Context {
+	return context.WithValue(ctx, contextKeyWarnings, warnings)
+}
+
+// setWarnings sets the warnings set by WithWarnings to the warnings of the query
+func setWarnings(ctx context.Context, query warner) {
+	warnings, ok := ctx.Value(contextKeyWarnings).(*[]string)
+	if !ok || warnings == nil {
+		return
+	}
+	*warnings = query.Warnings()
+}
+
+// WithSchemaVersion returns a copy of ctx that sets schemaVersion to the schema version of the cluster
+// after a create, alter, or drop statement is executed with it. gocql waits for the nodes to agree on the schema
+// after a schema change, so it is read from system.local of a node. Other statements do not set it.
+// Exec returns an error when the schema version can not be read, the statement has been executed by then.
+func WithSchemaVersion(ctx context.Context, schemaVersion *gocql.UUID) context.Context {
+	return context.WithValue(ctx, contextKeySchemaVersion, schemaVersion)
+}
+
+// schemaVersionFromContext returns the schema version set by WithSchemaVersion when statement changes the schema
+func schemaVersionFromContext(ctx context.Context, statement string) (*gocql.UUID, bool) {
+	schemaVersion, ok := ctx.Value(contextKeySchemaVersion).(*gocql.UUID)
+	if !ok || schemaVersion == nil {
+		return nil, false
+	}
+	switch statementVerb(statement) {
+	case "create", "alter", "drop":
+		return schemaVersion, true
+	}
+	return nil, false
+}
+
+// setSchemaVersion sets schemaVersion to the schema version scanned by query
+func setSchemaVersion(schemaVersion *gocql.UUID, query rowScanner) error {
+	var version gocql.UUID
+	err := query.Scan(&version)
+	if err != nil {
+		return fmt.Errorf("schema version error: %v", err)
+	}
+	*schemaVersion = version
+	return nil
+}
+
+// WithCustomPayload returns a copy of ctx that sends customPayload with queries executed with it.
+// Custom payloads require protocol version 4 or later, queries return ErrCustomPayloadNotSupported
+// when the cluster protocol version is set lower.
+func WithCustomPayload(ctx context.Context, customPayload map[string][]byte) context.Context {
+	return context.WithValue(ctx, contextKeyCustomPayload, customPayload)
+}
+
+// customPayloadFromContext returns the custom payload set by WithCustomPayload
+func customPayloadFromContext(ctx context.Context) (map[string][]byte, bool) {
+	customPayload, ok := ctx.Value(contextKeyCustomPayload).(map[string][]byte)
+	return customPayload, ok
+}
+
+// WithResponseCustomPayload returns a copy of ctx that sets customPayload to the custom payload
+// returned by the server when a query is executed with it
+func WithResponseCustomPayload(ctx context.Context, customPayload *map[string][]byte) context.Context {
+	return context.WithValue(ctx, contextKeyResponseCustomPayload, customPayload)
+}
+
+// setResponseCustomPayload sets the custom payload set by WithResponseCustomPayload to the custom payload of the query
+func setResponseCustomPayload(ctx context.Context, query customPayloader) {
+	customPayload, ok := ctx.Value(contextKeyResponseCustomPayload).(*map[string][]byte)
+	if !ok || customPayload == nil {
+		return
+	}
+	*customPayload = query.GetCustomPayload()
+}
+
+// WithBatchType returns a copy of ctx that sets the batch type of transactions begun with it,
+// which is gocql LoggedBatch, UnloggedBatch, or CounterBatch. The default is LoggedBatch.
+func WithBatchType(ctx context.Context, batchType gocql.BatchType) context.Context {
+	return context.WithValue(ctx, contextKeyBatchType, batchType)
+}
+
+// batchTypeFromContext returns the batch type set by WithBatchType, or LoggedBatch if not set
+func batchTypeFromContext(ctx context.Context) gocql.BatchType {
+	batchType, ok := ctx.Value(contextKeyBatchType).(gocql.BatchType)
+	if !ok {
+		return gocql.LoggedBatch
+	}
+	return batchType
+}
+
+// checkContext checks the query options set in the context are supported by the protocol version,
+// 0 is any protocol version
+func checkContext(ctx context.Context, protoVersion int) error {
+	if _, ok := customPayloadFromContext(ctx); ok && protoVersion > 0 && protoVersion < 4 {
+		return ErrCustomPayloadNotSupported
+	}
+	if _, ok := pageSizeFromContext(ctx); ok && withoutPagingFromContext(ctx) {
+		return ErrWithoutPagingPageSize
+	}
+	return nil
+}
+
+// applyContext applies the query options set in the context to the query,
+// a SliceTracer selects the trace events with session
+func applyContext(ctx context.Context, session *gocql.Session, query *gocql.Query) *gocql.Query {
+	if consistency, ok := consistencyFromContext(ctx); ok {
+		query = query.Consistency(consistency)
+	}
+	if tracer, ok := tracerFromContext(ctx); ok {
+		query = query.Trace(sessionTracer(tracer, session))
+	}
+	if pageSize, ok := pageSizeFromContext(ctx); ok {
+		query = query.PageSize(pageSize)
+	}
+	if withoutPagingFromContext(ctx) {
+		query = query.PageSize(0)
+	}
+	if pageState, ok := pageStateFromContext(ctx); ok {
+		query = query.PageState(pageState.pageState)
+	}
+	if idempotent, ok := idempotentFromContext(ctx); ok {
+		query = query.Idempotent(idempotent)
+	}
+	if policy, ok := speculativeExecutionFromContext(ctx); ok {
+		query = query.SetSpeculativeExecutionPolicy(policy)
+	}
+	if timestamp, ok := timestampFromContext(ctx); ok {
+		query = query.WithTimestamp(timestamp)
+	}
+	if serialConsistency, ok := serialConsistencyFromContext(ctx); ok {
+		query = query.SerialConsistency(serialConsistency)
+	}
+	if customPayload, ok := customPayloadFromContext(ctx); ok {
+		query = query.CustomPayload(customPayload)
+	}
+	if policy, ok := retryPolicyFromContext(ctx); ok {
+		query = query.RetryPolicy(policy)
+	}
+	return query
+}
+
+// applyBatchContext applies the batch options set in the context to the batch,
+// a SliceTracer selects the trace events with session
+func applyBatchContext(ctx context.Context, session *gocql.Session, batch *gocql.Batch) *gocql.Batch {
+	if consistency, ok := consistencyFromContext(ctx); ok {
+		batch.SetConsistency(consistency)
+	}
+	if tracer, ok := tracerFromContext(ctx); ok {
+		batch = batch.Trace(sessionTracer(tracer, session))
+	}
+	if policy, ok := speculativeExecutionFromContext(ctx); ok {
+		batch = batch.SpeculativeExecutionPolicy(policy)
+	}
+	if timestamp, ok := timestampFromContext(ctx); ok {
+		batch = batch.WithTimestamp(timestamp)
+	}
+	if serialConsistency, ok := serialConsistencyFromContext(ctx); ok {
+		batch = batch.SerialConsistency(serialConsistency)
+	}
+	if policy, ok := retryPolicyFromContext(ctx); ok {
+		batch = batch.RetryPolicy(policy)
+	}
+	return batch
+}
+
+// sessionTracer returns tracer, a SliceTracer with the session to select the trace events with
+func sessionTracer(tracer gocql.Tracer, session *gocql.Session) gocql.Tracer {
+	sliceTracer, ok := tracer.(*SliceTracer)
+	if !ok || session == nil {
+		return tracer
+	}
+	return sliceSessionTracer{sliceTracer: sliceTracer, session: session}
+}
+
+// Trace records the trace id
+func (sliceTracer *SliceTracer) Trace(traceID []byte) {
+	sliceTracer.trace(traceID, nil)
+}
+
+// Trace records the trace id and the trace events
+func (sessionTracer sliceSessionTracer) Trace(traceID []byte) {
+	sessionTracer.sliceTracer.trace(traceID, sessionTracer.session)
+}
+
+// trace records the trace id, and the trace events when session is not nil
+func (sliceTracer *SliceTracer) trace(traceID []byte, session *gocql.Session) {
+	uuid, err := gocql.UUIDFromBytes(traceID)
+	if err != nil {
+		return
+	}
+	sliceTracer.mutex.Lock()
+	sliceTracer.traceIDs = append(sliceTracer.traceIDs, uuid)
+	sliceTracer.mutex.Unlock()
+
+	if session == nil {
+		return
+	}
+	events, err := traceEvents(session, uuid)
+	sliceTracer.mutex.Lock()
+	sliceTracer.events = append(sliceTracer.events, events...)
+	if err != nil && sliceTracer.err == nil {
+		sliceTracer.err = err
+	}
+	sliceTracer.mutex.Unlock()
+}
+
+// traceEvents selects the events of the trace session traceID,
+// waiting for the trace session to finish since Cassandra writes traces asynchronously
+func traceEvents(session *gocql.Session, traceID gocql.UUID) ([]TraceEvent, error) {
+	var duration int
+	for i := 0; i < traceWaits; i++ {
+		err := session.Query(traceSessionStatement, traceID).Consistency(gocql.One).Scan(&duration)
+		if err != nil && err != gocql.ErrNotFound {
+			return nil, fmt.Errorf("trace session error: %v", err)
+		}
+		if duration > 0 {
+			break
+		}
+		time.Sleep(traceWait)
+	}
+
+	var events []TraceEvent
+	var elapsed int
+	event := TraceEvent{TraceID: traceID}
+	iter := session.Query(traceEventsStatement, traceID).Consistency(gocql.One).Iter()
+	for iter.Scan(&event.EventID, &event.Activity, &event.Source, &elapsed, &event.Thread) {
+		event.SourceElapsed = time.Duration(elapsed) * time.Microsecond
+		events = append(events, event)
+	}
+	err := iter.Close()
+	if err != nil {
+		return events, fmt.Errorf("trace events error: %v", err)
+	}
+	return events, nil
+}
+
+// TraceIDs returns a copy of the recorded trace ids in the order they were traced
+func (sliceTracer *SliceTracer) TraceIDs() []gocql.UUID {
+	sliceTracer.mutex.Lock()
+	defer sliceTracer.mutex.Unlock()
+	traceIDs := make([]gocql.UUID, len(sliceTracer.traceIDs))
+	copy(traceIDs, sliceTracer.traceIDs)
+	return traceIDs
+}
+
+// Events returns a copy of the recorded trace events in the order they were selected
+func (sliceTracer *SliceTracer) Events() []TraceEvent {
+	sliceTracer.mutex.Lock()
+	defer sliceTracer.mutex.Unlock()
+	events := make([]TraceEvent, len(sliceTracer.events))
+	copy(events, sliceTracer.events)
+	return events
+}
+
+// Err returns the first error selecting the trace events
+func (sliceTracer *SliceTracer) Err() error {
+	sliceTracer.mutex.Lock()
+	defer sliceTracer.mutex.Unlock()
+	return sliceTracer.err
+}
@@ -0,0 +1,447 @@
+package cql
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestContextConsistency(t *testing.T) {
+	query := new(gocql.Query).Consistency(gocql.One)
+	query = applyContext(context.Background(), nil, query)
+	if query.GetConsistency() != gocql.One {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.One)
+	}
+
+	ctx := WithConsistency(context.Background(), gocql.Quorum)
+	query = applyContext(ctx, nil, query)
+	if query.GetConsistency() != gocql.Quorum {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.Quorum)
+	}
+
+	ctx = WithConsistency(ctx, gocql.LocalOne)
+	query = applyContext(ctx, nil, query)
+	if query.GetConsistency() != gocql.LocalOne {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", query.GetConsistency(), gocql.LocalOne)
+	}
+
+	batch := applyBatchContext(ctx, nil, new(gocql.Batch))
+	if batch.GetConsistency() != gocql.LocalOne {
+		t.Fatalf("GetConsistency - received: %v - expected: %v ", batch.GetConsistency(), gocql.LocalOne)
+	}
+}
+
+func TestContextTracing(t *testing.T) {
+	_, ok := tracerFromContext(context.Background())
+	if ok {
+		t.Fatalf("tracerFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	sliceTracer := &SliceTracer{}
+	ctx := WithTracing(context.Background(), sliceTracer)
+	tracer, ok := tracerFromContext(ctx)
+	if !ok || tracer != sliceTracer {
+		t.Fatalf("tracerFromContext - received: %v - expected: %v ", tracer, sliceTracer)
+	}
+
+	_, ok = tracerFromContext(WithTracing(context.Background(), nil))
+	if ok {
+		t.Fatalf("tracerFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	uuid1 := gocql.TimeUUID()
+	uuid2 := gocql.TimeUUID()
+	sliceTracer.Trace(uuid1.Bytes())
+	sliceTracer.Trace([]byte{1, 2, 3})
+	sliceTracer.Trace(uuid2.Bytes())
+	traceIDs := sliceTracer.TraceIDs()
+	if len(traceIDs) != 2 || traceIDs[0] != uuid1 || traceIDs[1] != uuid2 {
+		t.Fatalf("TraceIDs - received: %v - expected: %v ", traceIDs, []gocql.UUID{uuid1, uuid2})
+	}
+	// without a session no events are selected
+	events := sliceTracer.Events()
+	if len(events) != 0 {
+		t.Fatalf("Events - received: %v - expected: %v ", events, []TraceEvent{})
+	}
+	if sliceTracer.Err() != nil {
+		t.Fatalf("Err - received: %v - expected: %v ", sliceTracer.Err(), nil)
+	}
+
+	if sessionTracer(sliceTracer, nil) != sliceTracer {
+		t.Fatalf("sessionTracer - received: %v - expected: %v ", sessionTracer(sliceTracer, nil), sliceTracer)
+	}
+	session := &gocql.Session{}
+	expected := sliceSessionTracer{sliceTracer: sliceTracer, session: session}
+	if sessionTracer(sliceTracer, session) != expected {
+		t.Fatalf("sessionTracer - received: %v - expected: %v ", sessionTracer(sliceTracer, session), expected)
+	}
+	traceWriter := gocql.NewTraceWriter(session, &bytes.Buffer{})
+	if sessionTracer(traceWriter, session) != traceWriter {
+		t.Fatalf("sessionTracer - received: %v - expected: %v ", sessionTracer(traceWriter, session), traceWriter)
+	}
+}
+
+func TestContextPageSize(t *testing.T) {
+	_, ok := pageSizeFromContext(context.Background())
+	if ok {
+		t.Fatalf("pageSizeFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	ctx := WithPageSize(context.Background(), 10)
+	pageSize, ok := pageSizeFromContext(ctx)
+	if !ok || pageSize != 10 {
+		t.Fatalf("pageSizeFromContext - received: %v - expected: %v ", pageSize, 10)
+	}
+
+	ctx = WithPageSize(ctx, 1)
+	pageSize, ok = pageSizeFromContext(ctx)
+	if !ok || pageSize != 1 {
+		t.Fatalf("pageSizeFromContext - received: %v - expected: %v ", pageSize, 1)
+	}
+}
+
+func TestContextWithoutPaging(t *testing.T) {
+	if withoutPagingFromContext(context.Background()) {
+		t.Fatalf("withoutPagingFromContext - received: %v - expected: %v ", true, false)
+	}
+
+	ctx := WithoutPaging(context.Background())
+	if !withoutPagingFromContext(ctx) {
+		t.Fatalf("withoutPagingFromContext - received: %v - expected: %v ", false, true)
+	}
+	err := checkContext(ctx, 4)
+	if err != nil {
+		t.Fatalf("checkContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	// WithoutPaging and WithPageSize are mutually exclusive
+	for _, ctx := range []context.Context{WithPageSize(ctx, 10), WithoutPaging(WithPageSize(context.Background(), 10))} {
+		err = checkContext(ctx, 4)
+		if err != ErrWithoutPagingPageSize {
+			t.Fatalf("checkContext error - received: %v - expected: %v ", err, ErrWithoutPagingPageSize)
+		}
+	}
+
+	cqlStmt := &CqlStmt{CqlQuery: new(gocql.Query), conn: &cqlConnStruct{clusterConfig: &gocql.ClusterConfig{ProtoVersion: 4}}}
+	_, err = cqlStmt.queryContext(WithPageSize(ctx, 10), nil)
+	if err != ErrWithoutPagingPageSize {
+		t.Fatalf("queryContext error - received: %v - expected: %v ", err, ErrWithoutPagingPageSize)
+	}
+}
+
+func TestContextPageState(t *testing.T) {
+	_, ok := pageStateFromContext(context.Background())
+	if ok {
+		t.Fatalf("pageStateFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	var nextPageState []byte
+	ctx := WithPageState(context.Background(), []byte{1, 2}, &nextPageState)
+	pageState, ok := pageStateFromContext(ctx)
+	if !ok || !bytes.Equal(pageState.pageState, []byte{1, 2}) || pageState.nextPageState != &nextPageState {
+		t.Fatalf("pageStateFromContext - received: %v - expected: %v ", pageState, pageStateValue{pageState: []byte{1, 2}, nextPageState: &nextPageState})
+	}
+
+	ctx = WithPageState(ctx, nil, nil)
+	pageState, ok = pageStateFromContext(ctx)
+	if !ok || pageState.pageState != nil || pageState.nextPageState != nil {
+		t.Fatalf("pageStateFromContext - received: %v - expected: %v ", pageState, pageStateValue{})
+	}
+}
+
+func TestContextIdempotent(t *testing.T) {
+	query := new(gocql.Query).Idempotent(false)
+	query = applyContext(context.Background(), nil, query)
+	if query.IsIdempotent() {
+		t.Fatalf("IsIdempotent - received: %v - expected: %v ", true, false)
+	}
+
+	ctx := WithIdempotent(context.Background(), true)
+	query = applyContext(ctx, nil, query)
+	if !query.IsIdempotent() {
+		t.Fatalf("IsIdempotent - received: %v - expected: %v ", false, true)
+	}
+
+	ctx = WithIdempotent(ctx, false)
+	query = applyContext(ctx, nil, query)
+	if query.IsIdempotent() {
+		t.Fatalf("IsIdempotent - received: %v - expected: %v ", true, false)
+	}
+}
+
+func TestContextSpeculativeExecution(t *testing.T) {
+	_, ok := speculativeExecutionFromContext(context.Background())
+	if ok {
+		t.Fatalf("speculativeExecutionFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	simpleSpeculativeExecution := NewSimpleSpeculativeExecution(2, 100*time.Millisecond)
+	if simpleSpeculativeExecution.Attempts() != 2 {
+		t.Fatalf("Attempts - received: %v - expected: %v ", simpleSpeculativeExecution.Attempts(), 2)
+	}
+	if simpleSpeculativeExecution.Delay() != 100*time.Millisecond {
+		t.Fatalf("Delay - received: %v - expected: %v ", simpleSpeculativeExecution.Delay(), 100*time.Millisecond)
+	}
+
+	ctx := WithSpeculativeExecution(context.Background(), simpleSpeculativeExecution)
+	policy, ok := speculativeExecutionFromContext(ctx)
+	if !ok || policy != simpleSpeculativeExecution {
+		t.Fatalf("speculativeExecutionFromContext - received: %v - expected: %v ", policy, simpleSpeculativeExecution)
+	}
+
+	_, ok = speculativeExecutionFromContext(WithSpeculativeExecution(context.Background(), nil))
+	if ok {
+		t.Fatalf("speculativeExecutionFromContext - received: %v - expected: %v ", ok, false)
+	}
+}
+
+func TestContextTimestamp(t *testing.T) {
+	_, ok := timestampFromContext(context.Background())
+	if ok {
+		t.Fatalf("timestampFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	ctx := WithTimestamp(context.Background(), 1234567890123456)
+	timestamp, ok := timestampFromContext(ctx)
+	if !ok || timestamp != 1234567890123456 {
+		t.Fatalf("timestampFromContext - received: %v - expected: %v ", timestamp, 1234567890123456)
+	}
+}
+
+func TestContextSerialConsistency(t *testing.T) {
+	_, ok := serialConsistencyFromContext(context.Background())
+	if ok {
+		t.Fatalf("serialConsistencyFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	ctx := WithSerialConsistency(context.Background(), gocql.LocalSerial)
+	serialConsistency, ok := serialConsistencyFromContext(ctx)
+	if !ok || serialConsistency != gocql.LocalSerial {
+		t.Fatalf("serialConsistencyFromContext - received: %v - expected: %v ", serialConsistency, gocql.LocalSerial)
+	}
+
+	ctx = WithSerialConsistency(ctx, gocql.Serial)
+	serialConsistency, ok = serialConsistencyFromContext(ctx)
+	if !ok || serialConsistency != gocql.Serial {
+		t.Fatalf("serialConsistencyFromContext - received: %v - expected: %v ", serialConsistency, gocql.Serial)
+	}
+}
+
+type testWarner []string
+
+func (warner testWarner) Warnings() []string {
+	return warner
+}
+
+type testAttempter struct {
+	attempts int
+	latency  int64
+	host     *gocql.HostInfo
+}
+
+func (attempter testAttempter) Attempts() int {
+	return attempter.attempts
+}
+
+func (attempter testAttempter) Latency() int64 {
+	return attempter.latency
+}
+
+func (attempter testAttempter) Host() *gocql.HostInfo {
+	return attempter.host
+}
+
+func TestContextQueryStats(t *testing.T) {
+	host := &gocql.HostInfo{}
+	attempter := testAttempter{attempts: 2, latency: int64(3 * time.Millisecond), host: host}
+
+	// without WithQueryStats
+	setQueryStats(context.Background(), attempter, attempter)
+
+	var stats QueryStats
+	ctx := WithQueryStats(context.Background(), &stats)
+	setQueryStats(ctx, attempter, attempter)
+	expected := QueryStats{Host: host, Attempts: 2, Latency: 3 * time.Millisecond}
+	if stats != expected {
+		t.Fatalf("stats - received: %v - expected: %v ", stats, expected)
+	}
+
+	setQueryStats(ctx, testAttempter{}, testAttempter{})
+	if stats != (QueryStats{}) {
+		t.Fatalf("stats - received: %v - expected: %v ", stats, QueryStats{})
+	}
+
+	// nil stats
+	setQueryStats(WithQueryStats(context.Background(), nil), attempter, attempter)
+}
+
+func TestContextRetryPolicy(t *testing.T) {
+	_, ok := retryPolicyFromContext(context.Background())
+	if ok {
+		t.Fatalf("retryPolicyFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	policy := &gocql.SimpleRetryPolicy{NumRetries: 5}
+	ctx := WithRetryPolicy(context.Background(), policy)
+	retryPolicy, ok := retryPolicyFromContext(ctx)
+	if !ok || retryPolicy != policy {
+		t.Fatalf("retryPolicyFromContext - received: %v - expected: %v ", retryPolicy, policy)
+	}
+}
+
+func TestContextWarnings(t *testing.T) {
+	// without WithWarnings
+	setWarnings(context.Background(), testWarner{"a"})
+
+	var warnings []string
+	ctx := WithWarnings(context.Background(), &warnings)
+	setWarnings(ctx, testWarner{"Read 1000 live rows and 5000 tombstone cells", "Batch is too large"})
+	expected := []string{"Read 1000 live rows and 5000 tombstone cells", "Batch is too large"}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Fatalf("warnings - received: %v - expected: %v ", warnings, expected)
+	}
+
+	setWarnings(ctx, testWarner(nil))
+	if warnings != nil {
+		t.Fatalf("warnings - received: %v - expected: %v ", warnings, nil)
+	}
+
+	// nil warnings
+	setWarnings(WithWarnings(context.Background(), nil), testWarner{"a"})
+}
+
+type testCustomPayloader map[string][]byte
+
+func (customPayloader testCustomPayloader) GetCustomPayload() map[string][]byte {
+	return customPayloader
+}
+
+func TestContextCustomPayload(t *testing.T) {
+	_, ok := customPayloadFromContext(context.Background())
+	if ok {
+		t.Fatalf("customPayloadFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	payload := map[string][]byte{"key": []byte("value")}
+	ctx := WithCustomPayload(context.Background(), payload)
+	customPayload, ok := customPayloadFromContext(ctx)
+	if !ok || !reflect.DeepEqual(customPayload, payload) {
+		t.Fatalf("customPayloadFromContext - received: %v - expected: %v ", customPayload, payload)
+	}
+
+	tests := []struct {
+		protoVersion int
+		err          error
+	}{
+		{protoVersion: 0, err: nil},
+		{protoVersion: 2, err: ErrCustomPayloadNotSupported},
+		{protoVersion: 3, err: ErrCustomPayloadNotSupported},
+		{protoVersion: 4, err: nil},
+		{protoVersion: 5, err: nil},
+	}
+
+	for _, test := range tests {
+		err := checkContext(ctx, test.protoVersion)
+		if err != test.err {
+			t.Fatalf("checkContext failed for: %v - received: %v - expected: %v", test.protoVersion, err, test.err)
+		}
+		err = checkContext(context.Background(), test.protoVersion)
+		if err != nil {
+			t.Fatalf("checkContext failed for: %v - received: %v - expected: %v", test.protoVersion, err, nil)
+		}
+	}
+
+	// statement with protocol version 3
+	cqlStmt := &CqlStmt{CqlQuery: new(gocql.Query), conn: &cqlConnStruct{clusterConfig: &gocql.ClusterConfig{ProtoVersion: 3}}}
+	_, err := cqlStmt.execContext(ctx, nil)
+	if err != ErrCustomPayloadNotSupported {
+		t.Fatalf("execContext error - received: %v - expected: %v ", err, ErrCustomPayloadNotSupported)
+	}
+	_, err = cqlStmt.queryContext(ctx, nil)
+	if err != ErrCustomPayloadNotSupported {
+		t.Fatalf("queryContext error - received: %v - expected: %v ", err, ErrCustomPayloadNotSupported)
+	}
+
+	// response custom payload round trip
+	setResponseCustomPayload(context.Background(), testCustomPayloader(payload))
+	var responsePayload map[string][]byte
+	ctx = WithResponseCustomPayload(ctx, &responsePayload)
+	setResponseCustomPayload(ctx, testCustomPayloader(customPayload))
+	if !reflect.DeepEqual(responsePayload, payload) {
+		t.Fatalf("response custom payload - received: %v - expected: %v ", responsePayload, payload)
+	}
+	setResponseCustomPayload(WithResponseCustomPayload(context.Background(), nil), testCustomPayloader(payload))
+}
+
+type testRowScanner struct {
+	version gocql.UUID
+	err     error
+}
+
+func (rowScanner testRowScanner) Scan(dest ...interface{}) error {
+	if rowScanner.err != nil {
+		return rowScanner.err
+	}
+	*dest[0].(*gocql.UUID) = rowScanner.version
+	return nil
+}
+
+func TestContextSchemaVersion(t *testing.T) {
+	_, ok := schemaVersionFromContext(context.Background(), "create table a (b int primary key)")
+	if ok {
+		t.Fatalf("schemaVersionFromContext - received: %v - expected: %v ", ok, false)
+	}
+	_, ok = schemaVersionFromContext(WithSchemaVersion(context.Background(), nil), "create table a (b int primary key)")
+	if ok {
+		t.Fatalf("schemaVersionFromContext - received: %v - expected: %v ", ok, false)
+	}
+
+	var schemaVersion gocql.UUID
+	ctx := WithSchemaVersion(context.Background(), &schemaVersion)
+
+	tests := []struct {
+		statement string
+		ok        bool
+	}{
+		{statement: "create table a (b int primary key)", ok: true},
+		{statement: "  ALTER TABLE a ADD c int", ok: true},
+		{statement: "drop table a", ok: true},
+		{statement: "/* comment */ create keyspace a with replication = {}", ok: true},
+		{statement: "select b from a", ok: false},
+		{statement: "insert into a (b) values (1)", ok: false},
+		{statement: "truncate a", ok: false},
+		{statement: "", ok: false},
+	}
+
+	for _, test := range tests {
+		version, ok := schemaVersionFromContext(ctx, test.statement)
+		if ok != test.ok {
+			t.Fatalf("schemaVersionFromContext failed for: %v - received: %v - expected: %v", test.statement, ok, test.ok)
+		}
+		if ok && version != &schemaVersion {
+			t.Fatalf("schemaVersionFromContext failed for: %v - received: %v - expected: %v", test.statement, version, &schemaVersion)
+		}
+	}
+
+	expected := gocql.TimeUUID()
+	err := setSchemaVersion(&schemaVersion, testRowScanner{version: expected})
+	if err != nil {
+		t.Fatalf("setSchemaVersion error - received: %v - expected: %v ", err, nil)
+	}
+	if schemaVersion != expected {
+		t.Fatalf("schemaVersion - received: %v - expected: %v ", schemaVersion, expected)
+	}
+
+	err = setSchemaVersion(&schemaVersion, testRowScanner{err: gocql.ErrNotFound})
+	expectedError := "schema version error: " + gocql.ErrNotFound.Error()
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("setSchemaVersion error - received: %v - expected: %v ", err, expectedError)
+	}
+	if schemaVersion != expected {
+		t.Fatalf("schemaVersion - received: %v - expected: %v ", schemaVersion, expected)
+	}
+}
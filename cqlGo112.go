@@ -0,0 +1,13 @@
+// +build go1.12
+
+package cql
+
+import (
+	"crypto/tls"
+)
+
+func init() {
+	// TLS 1.3 is in crypto/tls with Go 1.12 or later
+	DbSslVersions["1.3"] = tls.VersionTLS13
+	DbSslVersion[tls.VersionTLS13] = "1.3"
+}
@@ -0,0 +1,34 @@
+// +build go1.12
+
+package cql
+
+import (
+	"crypto/tls"
+	"reflect"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestConfigSslMinVersionTLS13(t *testing.T) {
+	clusterConfig := cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS13}})
+	configString := ClusterConfigToConfigString(clusterConfig)
+	expected := "127.0.0.1?enableHostVerification=false&sslInsecureSkipVerify=true&sslMinVersion=1.3"
+	if configString != expected {
+		t.Fatalf("configString - received: %#v - expected: %#v", configString, expected)
+	}
+
+	clusterConfig, err := ConfigStringToClusterConfig("?sslMinVersion=1.3")
+	if err != nil {
+		t.Fatalf("ConfigStringToClusterConfig error - received: %v - expected: %v ", err, nil)
+	}
+	expectedConfig := cfgWithSsl(&gocql.SslOptions{Config: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS13}})
+	if !reflect.DeepEqual(clusterConfig, expectedConfig) {
+		t.Fatalf("clusterConfig - received: %#v - expected: %#v", clusterConfig, expectedConfig)
+	}
+
+	err = RoundTrip(clusterConfig)
+	if err != nil {
+		t.Fatalf("RoundTrip error - received: %v - expected: %v ", err, nil)
+	}
+}
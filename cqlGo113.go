@@ -0,0 +1,30 @@
+// +build go1.13
+
+package cql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// AwaitSchemaAgreement waits for all nodes to agree on the schema version using a connection of db,
+// up to the ClusterConfig MaxWaitSchemaAgreement or the context deadline. Use it after DDL statements, like in migrations.
+// Returns ErrNotSupported if the db driver connection is not a SchemaAgreementAwaiter. Requires Go 1.13 or later.
+func AwaitSchemaAgreement(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	err = conn.Raw(func(driverConn interface{}) error {
+		awaiter, ok := driverConn.(SchemaAgreementAwaiter)
+		if !ok {
+			return ErrNotSupported
+		}
+		return awaiter.AwaitSchemaAgreement(ctx)
+	})
+	closeErr := conn.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,174 @@
+// +build go1.13
+
+package cql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// testSchemaConnector returns testSchemaConn connections that agree on the schema after delay
+type testSchemaConnector struct {
+	delay time.Duration
+}
+
+type testSchemaConn struct {
+	delay time.Duration
+}
+
+func (connector testSchemaConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return testSchemaConn{delay: connector.delay}, nil
+}
+
+func (connector testSchemaConnector) Driver() driver.Driver {
+	return CqlDriver
+}
+
+func (conn testSchemaConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, ErrNotSupported
+}
+
+func (conn testSchemaConn) Close() error {
+	return nil
+}
+
+func (conn testSchemaConn) Begin() (driver.Tx, error) {
+	return nil, ErrNotSupported
+}
+
+func (conn testSchemaConn) AwaitSchemaAgreement(ctx context.Context) error {
+	select {
+	case <-time.After(conn.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestConnectorAwaitSchemaAgreement(t *testing.T) {
+	var _ SchemaAgreementAwaiter = &cqlConnStruct{}
+
+	db := sql.OpenDB(testSchemaConnector{delay: 100 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err := AwaitSchemaAgreement(ctx, db)
+	cancel()
+	if err != nil {
+		t.Fatalf("AwaitSchemaAgreement error - received: %v - expected: %v ", err, nil)
+	}
+
+	// context deadline before agreement
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	err = AwaitSchemaAgreement(ctx, db)
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Fatalf("AwaitSchemaAgreement error - received: %v - expected: %v ", err, context.DeadlineExceeded)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestSqlAwaitSchemaAgreement(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = AwaitSchemaAgreement(ctx, db)
+	cancel()
+	if err != nil {
+		t.Fatal("AwaitSchemaAgreement error: ", err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func init() {
+	testAddGoVersionSubtest("TestSqlTime", "NullTime", testSqlNullTime)
+}
+
+// testSqlNullTime is a subtest of TestSqlTime, sql.NullTime needs Go 1.13 or later
+func testSqlNullTime(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert timestamp and date
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, timestamp_data, date_data) values (?, ?, ?)", "null time", TestTimeNow, TestTimeNow)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	// insert null timestamp and date
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data) values (?)", "null time null")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	// select timestamp and date into sql.NullTime
+	var timestampNull sql.NullTime
+	var dateNull sql.NullTime
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select timestamp_data, date_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "null time").Scan(&timestampNull, &dateNull)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if !timestampNull.Valid || !timestampNull.Time.Equal(TestTimeNow) {
+		t.Fatalf("timestamp_data - received: %v - expected: %v", timestampNull.Time, TestTimeNow)
+	}
+	expectedDate := time.Date(TestTimeNow.Year(), TestTimeNow.Month(), TestTimeNow.Day(), 0, 0, 0, 0, time.UTC)
+	if !dateNull.Valid || !dateNull.Time.Equal(expectedDate) {
+		t.Fatalf("date_data - received: %v - expected: %v", dateNull.Time, expectedDate)
+	}
+
+	// select null timestamp and date into sql.NullTime
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select timestamp_data, date_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "null time null").Scan(&timestampNull, &dateNull)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if timestampNull.Valid || !timestampNull.Time.IsZero() {
+		t.Fatalf("timestamp_data - received: %v - expected: %v", timestampNull, sql.NullTime{})
+	}
+	if dateNull.Valid || !dateNull.Time.IsZero() {
+		t.Fatalf("date_data - received: %v - expected: %v", dateNull, sql.NullTime{})
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
@@ -0,0 +1,85 @@
+// +build go1.27
+
+package cql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
+)
+
+func init() {
+	// ScanColumn scans uuid and timeuuid columns into gocql.UUID with Go 1.27 or later
+	uuidScanType = reflect.TypeOf(gocql.UUID{})
+}
+
+// NextRow reads the next row for ScanColumn, database/sql calls it instead of Next with Go 1.27 or later.
+// Returns io.EOF when there are no more rows.
+func (cqlRows *cqlRowsStruct) NextRow() error {
+	if len(cqlRows.row) != len(cqlRows.columns) {
+		cqlRows.row = make([]driver.Value, len(cqlRows.columns))
+	}
+	return cqlRows.Next(cqlRows.row)
+}
+
+// ScanColumn scans the column at index of the row read by NextRow into dest.
+// Values are the same as Next, except a null column scans into a *time.Time as the zero time and into a *net.IP as nil,
+// a uuid or timeuuid column scans into a *gocql.UUID, an inet column scans into a *string or sql.Scanner as text,
+// and decimal and varint columns scan into an *inf.Dec and a *big.Int, or a **inf.Dec and a **big.Int as before they were strings.
+func (cqlRows *cqlRowsStruct) ScanColumn(scanCtx driver.ScanContext, index int, dest interface{}) error {
+	if index < 0 || index >= len(cqlRows.row) {
+		return fmt.Errorf("column index out of range: %v", index)
+	}
+	value := cqlRows.row[index]
+	switch d := dest.(type) {
+	case *time.Time:
+		if value == nil {
+			*d = time.Time{}
+			return nil
+		}
+	case *net.IP:
+		if value == nil {
+			*d = nil
+			return nil
+		}
+	case *string, sql.Scanner:
+		if ip, ok := value.(net.IP); ok {
+			return sql.ConvertAssign(scanCtx, dest, ip.String())
+		}
+	case *gocql.UUID:
+		if text, ok := value.(string); ok {
+			uuid, err := gocql.ParseUUID(text)
+			if err != nil {
+				return err
+			}
+			*d = uuid
+			return nil
+		}
+	case *inf.Dec:
+		if text, ok := value.(string); ok {
+			return (*Decimal)(d).Scan(text)
+		}
+	case **inf.Dec:
+		if text, ok := value.(string); ok {
+			*d = new(inf.Dec)
+			return (*Decimal)(*d).Scan(text)
+		}
+	case *big.Int:
+		if text, ok := value.(string); ok {
+			return (*Varint)(d).Scan(text)
+		}
+	case **big.Int:
+		if text, ok := value.(string); ok {
+			*d = new(big.Int)
+			return (*Varint)(*d).Scan(text)
+		}
+	}
+	return sql.ConvertAssign(scanCtx, dest, value)
+}
@@ -0,0 +1,351 @@
+// +build go1.27
+
+package cql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
+)
+
+func init() {
+	testAddGoVersionSubtest("TestSqlTime", "TimeZero", testSqlTimeZero)
+	testAddGoVersionSubtest("TestSqlUUID", "GocqlUUID", testSqlGocqlUUID)
+	testAddGoVersionSubtest("TestSqlInet", "String", testSqlInetString)
+	testAddGoVersionSubtest("TestSqlBigNumber", "Native", testSqlBigNumberNative)
+}
+
+func TestRowsScanColumn(t *testing.T) {
+	var _ driver.RowsColumnScanner = &cqlRowsStruct{}
+
+	timeNow := time.Now().UTC()
+	cqlRows := &cqlRowsStruct{columns: []string{"null", "time"}, row: []driver.Value{nil, timeNow}}
+
+	// null into time.Time is the zero time
+	timeData := timeNow
+	err := cqlRows.ScanColumn(driver.ScanContext{}, 0, &timeData)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if !timeData.IsZero() {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", timeData, time.Time{})
+	}
+
+	// null into sql.NullTime is not valid
+	timeNull := sql.NullTime{Time: timeNow, Valid: true}
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, &timeNull)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if timeNull.Valid {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", timeNull, sql.NullTime{})
+	}
+
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 1, &timeData)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if !timeData.Equal(timeNow) {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", timeData, timeNow)
+	}
+
+	// uuid into gocql.UUID
+	cqlUUID := gocql.TimeUUID()
+	cqlRows = &cqlRowsStruct{columns: []string{"uuid", "text"}, row: []driver.Value{cqlUUID.String(), "a"}}
+	var uuid gocql.UUID
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, &uuid)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if uuid != cqlUUID {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", uuid, cqlUUID)
+	}
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 1, &uuid)
+	if err == nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, "invalid UUID")
+	}
+
+	// inet into string, sql.NullString, and net.IP
+	cqlRows = &cqlRowsStruct{columns: []string{"inet", "null"}, row: []driver.Value{net.IP{127, 0, 0, 1}, nil}}
+	var ipString string
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, &ipString)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if ipString != "127.0.0.1" {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", ipString, "127.0.0.1")
+	}
+	var ipNullString sql.NullString
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, &ipNullString)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if !ipNullString.Valid || ipNullString.String != "127.0.0.1" {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", ipNullString, "127.0.0.1")
+	}
+	ip := net.ParseIP("10.0.0.1")
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 1, &ip)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if ip != nil {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", ip, nil)
+	}
+
+	// decimal and varint into inf.Dec and big.Int
+	large := "123456789012345678901234567890"
+	cqlRows = &cqlRowsStruct{columns: []string{"decimal", "varint", "null"}, row: []driver.Value{"1234567890.123", large, nil}}
+	decimal := new(inf.Dec)
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, decimal)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if decimal.String() != "1234567890.123" {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", decimal, "1234567890.123")
+	}
+	var decimalPointer *inf.Dec
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, &decimalPointer)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if decimalPointer == nil || decimalPointer.String() != "1234567890.123" {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", decimalPointer, "1234567890.123")
+	}
+	varint := new(big.Int)
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 1, varint)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if varint.String() != large {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", varint, large)
+	}
+	var varintPointer *big.Int
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 1, &varintPointer)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if varintPointer == nil || varintPointer.String() != large {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", varintPointer, large)
+	}
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 2, &varintPointer)
+	if err != nil {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, nil)
+	}
+	if varintPointer != nil {
+		t.Fatalf("ScanColumn - received: %v - expected: %v", varintPointer, nil)
+	}
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 0, varint)
+	expectedError := "invalid varint: 1234567890.123"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	if uuidScanType != reflect.TypeOf(gocql.UUID{}) {
+		t.Fatalf("uuidScanType - received: %v - expected: %v", uuidScanType, reflect.TypeOf(gocql.UUID{}))
+	}
+
+	err = cqlRows.ScanColumn(driver.ScanContext{}, 3, &timeData)
+	expectedError = "column index out of range: 3"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("ScanColumn error - received: %v - expected: %v ", err, expectedError)
+	}
+}
+
+// testSqlTimeZero is a subtest of TestSqlTime, ScanColumn needs Go 1.27 or later
+func testSqlTimeZero(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// select null timestamp and date into time.Time
+	timestampData := TestTimeNow
+	dateData := TestTimeNow
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select timestamp_data, date_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "time null").Scan(&timestampData, &dateData)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if !timestampData.IsZero() {
+		t.Fatalf("timestamp_data - received: %v - expected: %v", timestampData, time.Time{})
+	}
+	if !dateData.IsZero() {
+		t.Fatalf("date_data - received: %v - expected: %v", dateData, time.Time{})
+	}
+
+	// select null timestamp and date into sql.NullTime
+	timestampNull := sql.NullTime{Time: TestTimeNow, Valid: true}
+	var dateNull sql.NullTime
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select timestamp_data, date_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "time null").Scan(&timestampNull, &dateNull)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if timestampNull.Valid {
+		t.Fatalf("timestamp_data - received: %v - expected: %v", timestampNull, sql.NullTime{})
+	}
+	if dateNull.Valid {
+		t.Fatalf("date_data - received: %v - expected: %v", dateNull, sql.NullTime{})
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+// testSqlGocqlUUID is a subtest of TestSqlUUID, ScanColumn needs Go 1.27 or later
+func testSqlGocqlUUID(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// select uuids into gocql.UUID and string
+	var cqlUUID gocql.UUID
+	var cqlTimeUUID gocql.UUID
+	var uuidString string
+	var timeUUIDString string
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select uuid_data, timeuuid_data, uuid_data, timeuuid_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "uuid").Scan(&cqlUUID, &cqlTimeUUID, &uuidString, &timeUUIDString)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if cqlUUID.String() != uuidString {
+		t.Fatalf("uuid_data - received: %v - expected: %v", cqlUUID, uuidString)
+	}
+	if cqlTimeUUID.String() != timeUUIDString {
+		t.Fatalf("timeuuid_data - received: %v - expected: %v", cqlTimeUUID, timeUUIDString)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+// testSqlInetString is a subtest of TestSqlInet, ScanColumn needs Go 1.27 or later
+func testSqlInetString(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	tests := []struct {
+		key string
+		ip  net.IP
+	}{
+		{key: "inet ipv4", ip: net.ParseIP("192.168.1.10")},
+		{key: "inet ipv6", ip: net.ParseIP("2001:db8::68")},
+		{key: "inet null"},
+	}
+
+	for _, test := range tests {
+		// select inet into net.IP, string, and sql.NullString
+		ip := net.ParseIP("10.0.0.1")
+		var ipString string
+		var ipNullString sql.NullString
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		if test.ip == nil {
+			err = db.QueryRowContext(ctx, "select inet_data, inet_data from "+KeyspaceName+"."+TableName+" where text_data = ?", test.key).Scan(&ip, &ipNullString)
+		} else {
+			err = db.QueryRowContext(ctx, "select inet_data, inet_data, inet_data from "+KeyspaceName+"."+TableName+" where text_data = ?", test.key).Scan(&ip, &ipString, &ipNullString)
+		}
+		cancel()
+		if err != nil {
+			t.Fatal("Scan error: ", err)
+		}
+		if test.ip == nil {
+			if ip != nil || ipNullString.Valid {
+				t.Fatalf("inet_data - received: %v, %v - expected: %v", ip, ipNullString, nil)
+			}
+			continue
+		}
+		if !ip.Equal(test.ip) {
+			t.Fatalf("inet_data - received: %v - expected: %v", ip, test.ip)
+		}
+		if ipString != test.ip.String() {
+			t.Fatalf("inet_data - received: %v - expected: %v", ipString, test.ip.String())
+		}
+		if !ipNullString.Valid || ipNullString.String != test.ip.String() {
+			t.Fatalf("inet_data - received: %v - expected: %v", ipNullString, test.ip.String())
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+// testSqlBigNumberNative is a subtest of TestSqlBigNumber, ScanColumn needs Go 1.27 or later
+func testSqlBigNumberNative(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// select into inf.Dec and big.Int without cql.Decimal and cql.Varint
+	decimalData := new(inf.Dec)
+	var varintData *big.Int
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select decimal_data, varint_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "big number").Scan(decimalData, &varintData)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if decimalData.String() != "12345678901234567890.123456789" {
+		t.Fatalf("decimal_data - received: %v - expected: %v", decimalData, "12345678901234567890.123456789")
+	}
+	if varintData == nil || varintData.String() != "123456789012345678901234567890" {
+		t.Fatalf("varint_data - received: %v - expected: %v", varintData, "123456789012345678901234567890")
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
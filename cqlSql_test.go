@@ -1,11 +1,22 @@
 package cql
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"net"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
-	// "time"
+	"time"
+
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
 )
 
 func TestSqlOpen(t *testing.T) {
@@ -75,10 +86,33 @@ func TestSqlCreate(t *testing.T) {
 		t.Fatal("result is nil")
 	}
 
+	// create type
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "create type "+KeyspaceName+".address (street text, number int)")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
 	// create table
 	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
 	// removed duration_data duration
-	result, err = db.ExecContext(ctx, "create table "+KeyspaceName+"."+TableName+" (text_data text PRIMARY KEY, int_data int, timestamp_data timestamp, map_data map<text, text> )")
+	result, err = db.ExecContext(ctx, "create table "+KeyspaceName+"."+TableName+" (text_data text PRIMARY KEY, int_data int, timestamp_data timestamp, map_data map<text, text>, list_text_data list<text>, list_int_data list<int>, map_int_data map<text, int>, set_text_data set<text>, set_int_data set<int>, map_list_data map<text, frozen<list<int>>>, list_map_data list<frozen<map<text, int>>>, tuple_data tuple<text, int, boolean>, address_data frozen<address>, date_data date, uuid_data uuid, timeuuid_data timeuuid, decimal_data decimal, varint_data varint, inet_data inet, string_data text, boolean_data boolean, bigint_data bigint, double_data double )")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// create counter table
+	var schemaVersion gocql.UUID
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(WithSchemaVersion(ctx, &schemaVersion), "create table "+KeyspaceName+"."+CounterTableName+" (counter_key text PRIMARY KEY, counter_data counter)")
 	cancel()
 	if err != nil {
 		t.Fatal("ExecContext error: ", err)
@@ -86,6 +120,21 @@ func TestSqlCreate(t *testing.T) {
 	if result == nil {
 		t.Fatal("result is nil")
 	}
+	if schemaVersion == (gocql.UUID{}) {
+		t.Fatalf("schemaVersion - received: %v - expected: %v ", schemaVersion, "not zero")
+	}
+
+	// schema version of system.local
+	var localSchemaVersion gocql.UUID
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, schemaVersionStatement).Scan((*UUID)(&localSchemaVersion))
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if schemaVersion != localSchemaVersion {
+		t.Fatalf("schemaVersion - received: %v - expected: %v ", schemaVersion, localSchemaVersion)
+	}
 
 	err = db.Close()
 	if err != nil {
@@ -546,6 +595,2238 @@ func TestSqlInsertUpdateSelectDelete(t *testing.T) {
 	}
 }
 
+func TestSqlNamedValues(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// truncate table
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "truncate table "+KeyspaceName+"."+TableName)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// insert named
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (:text_data, :int_data)", sql.Named("int_data", 1), sql.Named("text_data", "one"))
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// insert positional
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)", "two", 2)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select named
+	var intData int
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select int_data from "+KeyspaceName+"."+TableName+" where text_data = :text_data", sql.Named("text_data", "one")).Scan(&intData)
+	cancel()
+	if err != nil {
+		t.Fatal("QueryRowContext error: ", err)
+	}
+	if intData != 1 {
+		t.Fatalf("int_data - received: %v - expected: %v", intData, 1)
+	}
+
+	// select positional
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "two").Scan(&intData)
+	cancel()
+	if err != nil {
+		t.Fatal("QueryRowContext error: ", err)
+	}
+	if intData != 2 {
+		t.Fatalf("int_data - received: %v - expected: %v", intData, 2)
+	}
+
+	// named value without bind marker
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (:text_data, :int_data)", sql.Named("text_data", "three"), sql.Named("bad_data", 3))
+	cancel()
+	expectedError := "no bind marker for named value: bad_data"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	// mixed named and positional
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (:text_data, ?)", sql.Named("text_data", "three"), 3)
+	cancel()
+	if err == nil || err != ErrNamedValuesMixed {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, ErrNamedValuesMixed)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlBatchExec(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	batcher, ok := conn.(Batcher)
+	if !ok {
+		t.Fatal("conn is not a Batcher")
+	}
+
+	// truncate table
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err := conn.(driver.ExecerContext).ExecContext(ctx, "truncate table "+KeyspaceName+"."+TableName, nil)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	// unlogged batch
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = batcher.BatchExec(ctx, gocql.UnloggedBatch, []BatchStatement{
+		{Statement: "insert into " + KeyspaceName + "." + TableName + " (text_data, int_data) values (?, ?)", Values: []interface{}{"one", 1}},
+		{Statement: "insert into " + KeyspaceName + "." + TableName + " (text_data, int_data) values (?, ?)", Values: []interface{}{"two", 2}},
+		{Statement: "update " + KeyspaceName + "." + TableName + " set int_data = ? where text_data = ?", Values: []interface{}{3, "three"}},
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("BatchExec error - received: %v - expected: %v ", err, nil)
+	}
+
+	// select all
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := conn.(driver.QueryerContext).QueryContext(ctx, "select text_data, int_data from "+KeyspaceName+"."+TableName, nil)
+	if err != nil {
+		t.Fatal("QueryContext error: ", err)
+	}
+	data := make(map[string]int)
+	dest := make([]driver.Value, 2)
+	for rows.Next(dest) == nil {
+		data[dest[0].(string)] = dest[1].(int)
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+	expected := map[string]int{"one": 1, "two": 2, "three": 3}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("data - received: %v - expected: %v", data, expected)
+	}
+
+	// empty batch
+	err = batcher.BatchExec(context.Background(), gocql.LoggedBatch, nil)
+	if err == nil || err != ErrBatchIsEmpty {
+		t.Fatalf("BatchExec error - received: %v - expected: %v ", err, ErrBatchIsEmpty)
+	}
+
+	// invalid batch type
+	err = batcher.BatchExec(context.Background(), gocql.BatchType(10), []BatchStatement{{Statement: "select * from system.local"}})
+	expectedError := "invalid batch type: 10"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("BatchExec error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestSqlTx(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// truncate table
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "truncate table "+KeyspaceName+"."+TableName)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	// commit
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	tx, err := db.BeginTx(WithBatchType(ctx, gocql.UnloggedBatch), nil)
+	if err != nil {
+		cancel()
+		t.Fatal("BeginTx error: ", err)
+	}
+	_, err = tx.Exec("insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)", "one", 1)
+	if err != nil {
+		cancel()
+		t.Fatal("Exec error: ", err)
+	}
+	_, err = tx.Exec("update "+KeyspaceName+"."+TableName+" set int_data = ? where text_data = ?", 2, "two")
+	if err != nil {
+		cancel()
+		t.Fatal("Exec error: ", err)
+	}
+	var data int
+	err = tx.QueryRow("select int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "one").Scan(&data)
+	if err == nil || err != ErrTxQueryNotSupported {
+		cancel()
+		t.Fatalf("QueryRow error - received: %v - expected: %v ", err, ErrTxQueryNotSupported)
+	}
+	err = tx.Commit()
+	cancel()
+	if err != nil {
+		t.Fatalf("Commit error - received: %v - expected: %v ", err, nil)
+	}
+
+	// rollback
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	tx, err = db.BeginTx(ctx, nil)
+	if err != nil {
+		cancel()
+		t.Fatal("BeginTx error: ", err)
+	}
+	_, err = tx.Exec("insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)", "three", 3)
+	if err != nil {
+		cancel()
+		t.Fatal("Exec error: ", err)
+	}
+	err = tx.Rollback()
+	cancel()
+	if err != nil {
+		t.Fatalf("Rollback error - received: %v - expected: %v ", err, nil)
+	}
+
+	// select all
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := db.QueryContext(ctx, "select text_data, int_data from "+KeyspaceName+"."+TableName)
+	if err != nil {
+		cancel()
+		t.Fatal("QueryContext error: ", err)
+	}
+	result := make(map[string]int)
+	for rows.Next() {
+		var text string
+		err = rows.Scan(&text, &data)
+		if err != nil {
+			cancel()
+			t.Fatal("Scan error: ", err)
+		}
+		result[text] = data
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+	expected := map[string]int{"one": 1, "two": 2}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("data - received: %v - expected: %v", result, expected)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlCounter(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// increment and decrement
+	for _, delta := range []int64{5, 3, -2} {
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		_, err = db.ExecContext(ctx, "update "+KeyspaceName+"."+CounterTableName+" set counter_data = counter_data + ? where counter_key = ?", delta, "exec")
+		cancel()
+		if err != nil {
+			t.Fatal("ExecContext error: ", err)
+		}
+	}
+
+	var counter int64
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select counter_data from "+KeyspaceName+"."+CounterTableName+" where counter_key = ?", "exec").Scan(&counter)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if counter != 6 {
+		t.Fatalf("counter_data - received: %v - expected: %v", counter, 6)
+	}
+
+	// counter batch
+	conn := testGetConnectionHostValid(t)
+	batcher := conn.(Batcher)
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = batcher.BatchExec(ctx, gocql.CounterBatch, []BatchStatement{
+		{Statement: "update " + KeyspaceName + "." + CounterTableName + " set counter_data = counter_data + ? where counter_key = ?", Values: []interface{}{int64(1), "batch one"}},
+		{Statement: "update " + KeyspaceName + "." + CounterTableName + " set counter_data = counter_data + ? where counter_key = ?", Values: []interface{}{int64(2), "batch two"}},
+		{Statement: "update " + KeyspaceName + "." + CounterTableName + " set counter_data = counter_data + ? where counter_key = ?", Values: []interface{}{int64(3), "batch one"}},
+	})
+	cancel()
+	if err != nil {
+		t.Fatalf("BatchExec error - received: %v - expected: %v ", err, nil)
+	}
+
+	// counter updates are rejected in a logged batch
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = batcher.BatchExec(ctx, gocql.LoggedBatch, []BatchStatement{
+		{Statement: "update " + KeyspaceName + "." + CounterTableName + " set counter_data = counter_data + ? where counter_key = ?", Values: []interface{}{int64(1), "batch one"}},
+	})
+	cancel()
+	if err == nil {
+		t.Fatalf("BatchExec error - received: %v - expected: %v ", err, "error")
+	}
+	err = conn.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := db.QueryContext(ctx, "select counter_key, counter_data from "+KeyspaceName+"."+CounterTableName+" where counter_key in ('batch one', 'batch two')")
+	if err != nil {
+		cancel()
+		t.Fatal("QueryContext error: ", err)
+	}
+	data := make(map[string]int64)
+	for rows.Next() {
+		var key string
+		err = rows.Scan(&key, &counter)
+		if err != nil {
+			cancel()
+			t.Fatal("Scan error: ", err)
+		}
+		data[key] = counter
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+	expected := map[string]int64{"batch one": 4, "batch two": 2}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("data - received: %v - expected: %v", data, expected)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlExecCAS(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	conn := testGetConnectionHostValid(t)
+	if conn == nil {
+		t.Fatal("conn is nil")
+	}
+	casExecer, ok := conn.(CASExecer)
+	if !ok {
+		t.Fatal("conn is not a CASExecer")
+	}
+
+	tests := []struct {
+		statement        string
+		values           []interface{}
+		expectedApplied  bool
+		expectedExisting map[string]interface{}
+	}{
+		{statement: "delete from " + KeyspaceName + "." + TableName + " where text_data = ? if exists", values: []interface{}{"cas"},
+			expectedApplied: false, expectedExisting: map[string]interface{}{}},
+		{statement: "insert into " + KeyspaceName + "." + TableName + " (text_data, int_data) values (?, ?) if not exists", values: []interface{}{"cas", 1},
+			expectedApplied: true, expectedExisting: map[string]interface{}{}},
+		{statement: "update " + KeyspaceName + "." + TableName + " set int_data = ? where text_data = ? if int_data = ?", values: []interface{}{2, "cas", 3},
+			expectedApplied: false, expectedExisting: map[string]interface{}{"int_data": 1}},
+		{statement: "update " + KeyspaceName + "." + TableName + " set int_data = ? where text_data = ? if int_data = ?", values: []interface{}{2, "cas", 1},
+			expectedApplied: true, expectedExisting: map[string]interface{}{}},
+		{statement: "delete from " + KeyspaceName + "." + TableName + " where text_data = ? if exists", values: []interface{}{"cas"},
+			expectedApplied: true, expectedExisting: map[string]interface{}{}},
+	}
+
+	for _, test := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		applied, existing, err := casExecer.ExecCAS(ctx, test.statement, test.values...)
+		cancel()
+		if err != nil {
+			t.Fatalf("ExecCAS failed for: %v - received: %v - expected: %v", test.statement, err, nil)
+		}
+		if applied != test.expectedApplied {
+			t.Fatalf("ExecCAS failed for: %v - received: %v - expected: %v", test.statement, applied, test.expectedApplied)
+		}
+		if !reflect.DeepEqual(existing, test.expectedExisting) {
+			t.Fatalf("ExecCAS failed for: %v - received: %v - expected: %v", test.statement, existing, test.expectedExisting)
+		}
+	}
+
+	// insert if not exists returns the existing row
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	applied, _, err := casExecer.ExecCAS(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?) if not exists", "cas", 4)
+	cancel()
+	if err != nil || !applied {
+		t.Fatalf("ExecCAS - received: %v, %v - expected: %v, %v", applied, err, true, nil)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	applied, existing, err := casExecer.ExecCAS(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?) if not exists", "cas", 5)
+	cancel()
+	if err != nil || applied {
+		t.Fatalf("ExecCAS - received: %v, %v - expected: %v, %v", applied, err, false, nil)
+	}
+	if existing["text_data"] != "cas" || existing["int_data"] != 4 {
+		t.Fatalf("ExecCAS existing - received: %v - expected: %v", existing, map[string]interface{}{"text_data": "cas", "int_data": 4})
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlPageSize(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	conn, err := CqlDriver.Open(TestHostValid + "?timeout=10s&connectTimeout=10s&pageSize=3")
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	cqlConn := conn.(*cqlConnStruct)
+	if EnableAuthentication {
+		cqlConn.clusterConfig.Authenticator = gocql.PasswordAuthenticator{Username: Username, Password: Password}
+	}
+	queryer := conn.(driver.QueryerContext)
+
+	// insert rows
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		_, err = conn.(driver.ExecerContext).ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)",
+			[]driver.NamedValue{{Ordinal: 1, Value: fmt.Sprintf("page size %v", i)}, {Ordinal: 2, Value: i}})
+		cancel()
+		if err != nil {
+			t.Fatal("ExecContext error: ", err)
+		}
+	}
+
+	tests := []struct {
+		ctx      context.Context
+		expected int
+	}{
+		{ctx: context.Background(), expected: 3},
+		{ctx: WithPageSize(context.Background(), 1), expected: 1},
+		{ctx: WithPageSize(context.Background(), 2), expected: 2},
+		{ctx: context.Background(), expected: 3},
+	}
+
+	for _, test := range tests {
+		ctx, cancel := context.WithTimeout(test.ctx, TimeoutValid)
+		rows, err := queryer.QueryContext(ctx, "select text_data from "+KeyspaceName+"."+TableName, nil)
+		if err != nil {
+			cancel()
+			t.Fatal("QueryContext error: ", err)
+		}
+		numRows := rows.(*cqlRowsStruct).iter.NumRows()
+		err = rows.Close()
+		cancel()
+		if err != nil {
+			t.Fatal("Close error: ", err)
+		}
+		if numRows != test.expected {
+			t.Fatalf("NumRows - received: %v - expected: %v", numRows, test.expected)
+		}
+	}
+
+	// without paging all rows are in one page
+	ctx, cancel := context.WithTimeout(WithoutPaging(context.Background()), TimeoutValid)
+	rows, err := queryer.QueryContext(ctx, "select text_data from "+KeyspaceName+"."+TableName, nil)
+	if err != nil {
+		cancel()
+		t.Fatal("QueryContext error: ", err)
+	}
+	numRows := rows.(*cqlRowsStruct).iter.NumRows()
+	pageState := rows.(*cqlRowsStruct).iter.PageState()
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+	if numRows < 5 {
+		t.Fatalf("NumRows - received: %v - expected: %v", numRows, "at least 5")
+	}
+	if len(pageState) != 0 {
+		t.Fatalf("PageState - received: %v - expected: %v", pageState, "empty")
+	}
+
+	err = conn.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlPageState(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert rows
+	for i := 0; i < 4; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)", fmt.Sprintf("page state %v", i), i)
+		cancel()
+		if err != nil {
+			t.Fatal("ExecContext error: ", err)
+		}
+	}
+
+	selectKeys := func(ctx context.Context) []string {
+		ctx, cancel := context.WithTimeout(ctx, TimeoutValid)
+		defer cancel()
+		rows, err := db.QueryContext(ctx, "select text_data from "+KeyspaceName+"."+TableName)
+		if err != nil {
+			t.Fatal("QueryContext error: ", err)
+		}
+		var keys []string
+		for rows.Next() {
+			var key string
+			err = rows.Scan(&key)
+			if err != nil {
+				t.Fatal("Scan error: ", err)
+			}
+			keys = append(keys, key)
+		}
+		err = rows.Close()
+		if err != nil {
+			t.Fatal("Close error: ", err)
+		}
+		return keys
+	}
+
+	// all rows without paging
+	expected := selectKeys(context.Background())
+	pageSize := (len(expected) + 1) / 2
+
+	// first page
+	var nextPageState []byte
+	keys := selectKeys(WithPageSize(WithPageState(context.Background(), nil, &nextPageState), pageSize))
+	if len(keys) != pageSize {
+		t.Fatalf("first page keys - received: %v - expected: %v", len(keys), pageSize)
+	}
+	if len(nextPageState) < 1 {
+		t.Fatal("first page nextPageState is empty")
+	}
+
+	// second page
+	pageState := nextPageState
+	keys = append(keys, selectKeys(WithPageSize(WithPageState(context.Background(), pageState, &nextPageState), pageSize))...)
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("keys - received: %v - expected: %v", keys, expected)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlTimestamp(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert with timestamp
+	timestamp := TestTimeNow.UnixNano() / 1000
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(WithTimestamp(ctx, timestamp), "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)", "timestamp", 1)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	// older timestamp does not overwrite
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(WithTimestamp(ctx, timestamp-1), "update "+KeyspaceName+"."+TableName+" set int_data = ? where text_data = ?", 2, "timestamp")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	var intData int
+	var writeTime int64
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select int_data, writetime(int_data) from "+KeyspaceName+"."+TableName+" where text_data = ?", "timestamp").Scan(&intData, &writeTime)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if intData != 1 {
+		t.Fatalf("int_data - received: %v - expected: %v", intData, 1)
+	}
+	if writeTime != timestamp {
+		t.Fatalf("writetime - received: %v - expected: %v", writeTime, timestamp)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+type testRetryPolicy struct {
+	mutex    sync.Mutex
+	attempts int
+}
+
+func (policy *testRetryPolicy) Attempt(query gocql.RetryableQuery) bool {
+	policy.mutex.Lock()
+	policy.attempts++
+	policy.mutex.Unlock()
+	return false
+}
+
+func (policy *testRetryPolicy) GetRetryType(err error) gocql.RetryType {
+	return gocql.Rethrow
+}
+
+func TestSqlRetryPolicy(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// the retry policy is asked to retry a failed query with the context only
+	policy := &testRetryPolicy{}
+	tests := []struct {
+		ctx      context.Context
+		attempts int
+	}{
+		{ctx: WithRetryPolicy(context.Background(), policy), attempts: 1},
+		{ctx: context.Background(), attempts: 1},
+		{ctx: WithRetryPolicy(context.Background(), policy), attempts: 2},
+	}
+
+	for _, test := range tests {
+		ctx, cancel := context.WithTimeout(test.ctx, TimeoutValid)
+		_, err = db.ExecContext(ctx, "update "+KeyspaceName+"."+TableName+" set does_not_exist = 1 where text_data = 'retry policy'")
+		cancel()
+		if err == nil {
+			t.Fatalf("ExecContext error - received: %v - expected: %v", err, "error")
+		}
+		policy.mutex.Lock()
+		attempts := policy.attempts
+		policy.mutex.Unlock()
+		if attempts != test.attempts {
+			t.Fatalf("attempts - received: %v - expected: %v", attempts, test.attempts)
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlQueryStats(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	var execStats QueryStats
+	ctx, cancel := context.WithTimeout(WithQueryStats(context.Background(), &execStats), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?)", "query stats", 1)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	var queryStats QueryStats
+	ctx, cancel = context.WithTimeout(WithQueryStats(context.Background(), &queryStats), TimeoutValid)
+	rows, err := db.QueryContext(ctx, "select int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "query stats")
+	if err != nil {
+		cancel()
+		t.Fatal("QueryContext error: ", err)
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+
+	for _, stats := range []QueryStats{execStats, queryStats} {
+		if stats.Host == nil || stats.Host.ConnectAddress() == nil {
+			t.Fatalf("Host - received: %v - expected: %v", stats.Host, "host")
+		}
+		if stats.Attempts != 1 {
+			t.Fatalf("Attempts - received: %v - expected: %v", stats.Attempts, 1)
+		}
+		if stats.Latency <= 0 {
+			t.Fatalf("Latency - received: %v - expected: %v", stats.Latency, "greater than 0")
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlEnsureKeyspace(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// second call is a no-op for the existing keyspace
+	replication := map[string]interface{}{"class": "SimpleStrategy", "replication_factor": 1}
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		err = EnsureKeyspace(ctx, db, KeyspaceName+"_ensure", replication)
+		cancel()
+		if err != nil {
+			t.Fatal("EnsureKeyspace error: ", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "drop keyspace "+KeyspaceName+"_ensure")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlQueryRowNoRows(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	tests := []struct {
+		query string
+		args  []interface{}
+	}{
+		{query: "select int_data from " + KeyspaceName + "." + TableName + " where text_data = ?", args: []interface{}{"no rows"}},
+		{query: "select int_data from " + KeyspaceName + "." + TableName + " where text_data = 'no rows'"},
+	}
+
+	for i := 0; i < len(tests); i++ {
+		var data int
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		err = db.QueryRowContext(ctx, tests[i].query, tests[i].args...).Scan(&data)
+		cancel()
+		if err != sql.ErrNoRows {
+			t.Fatalf("Scan error - received: %v - expected: %v - query: %v", err, sql.ErrNoRows, tests[i].query)
+		}
+	}
+
+	// prepared statement
+	stmt, err := db.Prepare("select int_data from " + KeyspaceName + "." + TableName + " where text_data = ?")
+	if err != nil {
+		t.Fatal("Prepare error: ", err)
+	}
+	var data int
+	err = stmt.QueryRow("no rows").Scan(&data)
+	if err != sql.ErrNoRows {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, sql.ErrNoRows)
+	}
+	err = stmt.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlSerialConsistency(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s&serialConsistency=serial"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// local serial
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(WithSerialConsistency(ctx, gocql.LocalSerial), "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values (?, ?) if not exists", "serial consistency", 1)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	// a consistency that is not serial is rejected by the server, so the conditional query was sent with it
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(WithSerialConsistency(ctx, gocql.SerialConsistency(gocql.One)), "update "+KeyspaceName+"."+TableName+" set int_data = ? where text_data = ? if int_data = ?", 2, "serial consistency", 1)
+	cancel()
+	if err == nil {
+		t.Fatalf("ExecContext error - received: %v - expected: %v", err, "error")
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlMultipleResultSets(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values ('multiple;1', 1);"+
+		"insert into "+KeyspaceName+"."+TableName+" (text_data, int_data) values ('multiple;2', 2);")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := db.QueryContext(ctx, "select int_data from "+KeyspaceName+"."+TableName+" where text_data = 'multiple;1';"+
+		"update "+KeyspaceName+"."+TableName+" set int_data = 3 where text_data = 'multiple;2';"+
+		"select int_data from "+KeyspaceName+"."+TableName+" where text_data = 'multiple;2'")
+	if err != nil {
+		cancel()
+		t.Fatal("QueryContext error: ", err)
+	}
+
+	expected := [][]int{{1}, {}, {3}}
+	for i := 0; i < len(expected); i++ {
+		if i > 0 && !rows.NextResultSet() {
+			cancel()
+			t.Fatalf("NextResultSet %v - received: %v - expected: %v - error: %v", i, false, true, rows.Err())
+		}
+		var data []int
+		for rows.Next() {
+			var intData int
+			err = rows.Scan(&intData)
+			if err != nil {
+				cancel()
+				t.Fatal("Scan error: ", err)
+			}
+			data = append(data, intData)
+		}
+		if len(data) != len(expected[i]) || (len(data) > 0 && data[0] != expected[i][0]) {
+			cancel()
+			t.Fatalf("result set %v - received: %v - expected: %v", i, data, expected[i])
+		}
+	}
+	if rows.NextResultSet() {
+		cancel()
+		t.Fatalf("NextResultSet - received: %v - expected: %v", true, false)
+	}
+	err = rows.Err()
+	if err != nil {
+		cancel()
+		t.Fatal("Err error: ", err)
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.QueryContext(ctx, "select int_data from "+KeyspaceName+"."+TableName+" where text_data = ?; select int_data from "+KeyspaceName+"."+TableName, "multiple;1")
+	cancel()
+	if err != ErrMultipleStatementsValues {
+		t.Fatalf("QueryContext error - received: %v - expected: %v", err, ErrMultipleStatementsValues)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlScanStruct(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, int_data, boolean_data, double_data, list_text_data, map_data) values (?, ?, ?, ?, ?, ?)",
+		"scan struct", 1, true, 2.5, []string{"a", "b"}, map[string]string{"c": "d"})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	type row struct {
+		Text    string            `cql:"text_data"`
+		Int     int               `cql:"int_data"`
+		Boolean bool              `cql:"boolean_data"`
+		Double  float64           `cql:"double_data"`
+		List    []string          `cql:"list_text_data"`
+		Map     map[string]string `cql:"map_data"`
+		Ignored string
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	rows, err := db.QueryContext(ctx, "select text_data, int_data, boolean_data, double_data, list_text_data, map_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "scan struct")
+	if err != nil {
+		cancel()
+		t.Fatal("QueryContext error: ", err)
+	}
+	if !rows.Next() {
+		cancel()
+		t.Fatal("Next error: ", rows.Err())
+	}
+	var data row
+	err = ScanStruct(rows, &data)
+	if err != nil {
+		cancel()
+		t.Fatal("ScanStruct error: ", err)
+	}
+	err = rows.Close()
+	cancel()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+	expected := row{Text: "scan struct", Int: 1, Boolean: true, Double: 2.5, List: []string{"a", "b"}, Map: map[string]string{"c": "d"}}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("ScanStruct - received: %#v - expected: %#v", data, expected)
+	}
+
+	// unmapped column into catch-all, and error without one
+	type catchAllRow struct {
+		Text string                 `cql:"text_data"`
+		Rest map[string]interface{} `cql:"*"`
+	}
+	for _, dest := range []interface{}{&catchAllRow{}, &struct {
+		Text string `cql:"text_data"`
+	}{}} {
+		ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+		rows, err = db.QueryContext(ctx, "select text_data, int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "scan struct")
+		if err != nil {
+			cancel()
+			t.Fatal("QueryContext error: ", err)
+		}
+		if !rows.Next() {
+			cancel()
+			t.Fatal("Next error: ", rows.Err())
+		}
+		err = ScanStruct(rows, dest)
+		rows.Close()
+		cancel()
+		if catchAll, ok := dest.(*catchAllRow); ok {
+			if err != nil {
+				t.Fatal("ScanStruct error: ", err)
+			}
+			if catchAll.Text != "scan struct" || catchAll.Rest["int_data"] != int64(1) {
+				t.Fatalf("ScanStruct - received: %#v - expected: %v", catchAll, "int_data 1 in Rest")
+			}
+		} else if err == nil || err.Error() != "no struct field for column: int_data" {
+			t.Fatalf("ScanStruct error - received: %v - expected: %v", err, "no struct field for column: int_data")
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlList(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert lists
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, list_text_data, list_int_data) values (?, ?, ?)", "list", []string{"a", "b"}, []int32{1, 2, 3})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// insert empty lists
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, list_text_data, list_int_data) values (?, ?, ?)", "list empty", []string{}, []int32{})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select lists
+	var listText []string
+	var listInt []int32
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select list_text_data, list_int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "list").Scan(&listText, &listInt)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if !reflect.DeepEqual(listText, []string{"a", "b"}) {
+		t.Fatalf("list_text_data - received: %v - expected: %v", listText, []string{"a", "b"})
+	}
+	if !reflect.DeepEqual(listInt, []int32{1, 2, 3}) {
+		t.Fatalf("list_int_data - received: %v - expected: %v", listInt, []int32{1, 2, 3})
+	}
+
+	// select empty lists
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select list_text_data, list_int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "list empty").Scan(&listText, &listInt)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if listText == nil || len(listText) != 0 {
+		t.Fatalf("list_text_data - received: %#v - expected: %#v", listText, []string{})
+	}
+	if listInt == nil || len(listInt) != 0 {
+		t.Fatalf("list_int_data - received: %#v - expected: %#v", listInt, []int32{})
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlNestedCollections(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert nested collections
+	mapList := map[string][]int32{"a": {1, 2}, "b": {}}
+	listMap := []map[string]int32{{"a": 1}, {"b": 2, "c": 3}}
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, map_list_data, list_map_data) values (?, ?, ?)", "nested", mapList, listMap)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select nested collections
+	var mapListData map[string][]int32
+	var listMapData []map[string]int32
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select map_list_data, list_map_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "nested").Scan(&mapListData, &listMapData)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if !reflect.DeepEqual(mapListData, mapList) {
+		t.Fatalf("map_list_data - received: %v - expected: %v", mapListData, mapList)
+	}
+	if !reflect.DeepEqual(listMapData, listMap) {
+		t.Fatalf("list_map_data - received: %v - expected: %v", listMapData, listMap)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlMap(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert maps
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, map_data, map_int_data) values (?, ?, ?)", "map", map[string]string{"a": "one", "b": "two"}, map[string]int32{"a": 1, "b": 2})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// insert null maps
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data) values (?)", "map null")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select maps
+	var mapText map[string]string
+	var mapInt map[string]int32
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select map_data, map_int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "map").Scan(&mapText, &mapInt)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if !reflect.DeepEqual(mapText, map[string]string{"a": "one", "b": "two"}) {
+		t.Fatalf("map_data - received: %v - expected: %v", mapText, map[string]string{"a": "one", "b": "two"})
+	}
+	if !reflect.DeepEqual(mapInt, map[string]int32{"a": 1, "b": 2}) {
+		t.Fatalf("map_int_data - received: %v - expected: %v", mapInt, map[string]int32{"a": 1, "b": 2})
+	}
+
+	// select null maps
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select map_data, map_int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "map null").Scan(&mapText, &mapInt)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if mapText != nil {
+		t.Fatalf("map_data - received: %v - expected: %v", mapText, nil)
+	}
+	if mapInt != nil {
+		t.Fatalf("map_int_data - received: %v - expected: %v", mapInt, nil)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlSet(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert sets
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, set_text_data, set_int_data) values (?, ?, ?)", "set", []string{"b", "a"}, []int32{3, 1, 2})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// insert empty sets
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, set_text_data, set_int_data) values (?, ?, ?)", "set empty", []string{}, []int32{})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select sets, order is not guaranteed
+	var setText []string
+	var setInt []int32
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select set_text_data, set_int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "set").Scan(&setText, &setInt)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	sort.Strings(setText)
+	if !reflect.DeepEqual(setText, []string{"a", "b"}) {
+		t.Fatalf("set_text_data - received: %v - expected: %v", setText, []string{"a", "b"})
+	}
+	sort.Slice(setInt, func(i, j int) bool { return setInt[i] < setInt[j] })
+	if !reflect.DeepEqual(setInt, []int32{1, 2, 3}) {
+		t.Fatalf("set_int_data - received: %v - expected: %v", setInt, []int32{1, 2, 3})
+	}
+
+	// select empty sets
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select set_text_data, set_int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "set empty").Scan(&setText, &setInt)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if setText == nil || len(setText) != 0 {
+		t.Fatalf("set_text_data - received: %#v - expected: %#v", setText, []string{})
+	}
+	if setInt == nil || len(setInt) != 0 {
+		t.Fatalf("set_int_data - received: %#v - expected: %#v", setInt, []int32{})
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlTuple(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert tuple
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, tuple_data, int_data) values (?, ?, ?)", "tuple", []interface{}{"a", 1, true}, 2)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select tuple, column after tuple
+	var text string
+	var number int32
+	var boolean bool
+	var intData int
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select tuple_data, int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "tuple").Scan(Tuple{&text, &number, &boolean}, &intData)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if text != "a" || number != 1 || !boolean {
+		t.Fatalf("tuple_data - received: %v, %v, %v - expected: %v, %v, %v", text, number, boolean, "a", 1, true)
+	}
+	if intData != 2 {
+		t.Fatalf("int_data - received: %v - expected: %v", intData, 2)
+	}
+
+	// select tuple into interface slice
+	tuple := []interface{}{new(string), new(int32), new(bool)}
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select tuple_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "tuple").Scan(Tuple(tuple))
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if *tuple[0].(*string) != "a" || *tuple[1].(*int32) != 1 || !*tuple[2].(*bool) {
+		t.Fatalf("tuple_data - received: %v, %v, %v - expected: %v, %v, %v", *tuple[0].(*string), *tuple[1].(*int32), *tuple[2].(*bool), "a", 1, true)
+	}
+
+	// select tuple with wrong length
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select tuple_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "tuple").Scan(Tuple{&text, &number})
+	cancel()
+	expectedError := "tuple has 3 elements, destination has 2"
+	if err == nil || !strings.HasSuffix(err.Error(), expectedError) {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+type testAddress struct {
+	street string
+	number int32
+}
+
+func (address *testAddress) UnmarshalUDT(name string, info gocql.TypeInfo, data []byte) error {
+	switch name {
+	case "street":
+		return gocql.Unmarshal(info, data, &address.street)
+	case "number":
+		return gocql.Unmarshal(info, data, &address.number)
+	}
+	return nil
+}
+
+func TestSqlUDT(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert udt
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, address_data) values (?, ?)", "udt", map[string]interface{}{"street": "main", "number": 1})
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select udt into map
+	var addressMap map[string]interface{}
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select address_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "udt").Scan(&addressMap)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	expected := map[string]interface{}{"street": "main", "number": 1}
+	if !reflect.DeepEqual(addressMap, expected) {
+		t.Fatalf("address_data - received: %v - expected: %v", addressMap, expected)
+	}
+
+	// select udt into struct
+	err = RegisterUDT(KeyspaceName, "address", &testAddress{})
+	if err != nil {
+		t.Fatalf("RegisterUDT error - received: %v - expected: %v ", err, nil)
+	}
+	var address testAddress
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select address_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "udt").Scan(&address)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if address.street != "main" || address.number != 1 {
+		t.Fatalf("address_data - received: %v - expected: %v", address, testAddress{street: "main", number: 1})
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlTime(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert timestamp and date
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, timestamp_data, date_data) values (?, ?, ?)", "time", TestTimeNow, TestTimeNow)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// insert null timestamp and date
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data) values (?)", "time null")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select timestamp and date
+	var timestampData time.Time
+	var dateData time.Time
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select timestamp_data, date_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "time").Scan(&timestampData, &dateData)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if !timestampData.Equal(TestTimeNow) {
+		t.Fatalf("timestamp_data - received: %v - expected: %v", timestampData, TestTimeNow)
+	}
+	expectedDate := time.Date(TestTimeNow.Year(), TestTimeNow.Month(), TestTimeNow.Day(), 0, 0, 0, 0, time.UTC)
+	if !dateData.Equal(expectedDate) || dateData.Location() != time.UTC {
+		t.Fatalf("date_data - received: %v - expected: %v", dateData, expectedDate)
+	}
+
+	testRunGoVersionSubtests(t)
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+type testScannerUUID [16]byte
+
+func (uuid *testScannerUUID) Scan(src interface{}) error {
+	text, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unable to scan type %T into UUID", src)
+	}
+	cqlUUID, err := gocql.ParseUUID(text)
+	if err != nil {
+		return err
+	}
+	*uuid = testScannerUUID(cqlUUID)
+	return nil
+}
+
+func TestSqlUUID(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	uuid, err := gocql.RandomUUID()
+	if err != nil {
+		t.Fatal("RandomUUID error: ", err)
+	}
+	timeUUID := gocql.TimeUUID()
+
+	// insert uuids
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, uuid_data, timeuuid_data) values (?, ?, ?)", "uuid", uuid, timeUUID.String())
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select uuids into string
+	var uuidString string
+	var timeUUIDString string
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select uuid_data, timeuuid_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "uuid").Scan(&uuidString, &timeUUIDString)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if uuidString != uuid.String() {
+		t.Fatalf("uuid_data - received: %v - expected: %v", uuidString, uuid.String())
+	}
+	if timeUUIDString != timeUUID.String() {
+		t.Fatalf("timeuuid_data - received: %v - expected: %v", timeUUIDString, timeUUID.String())
+	}
+
+	// select uuids into []byte
+	var uuidBytes []byte
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select uuid_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "uuid").Scan(&uuidBytes)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if string(uuidBytes) != uuid.String() {
+		t.Fatalf("uuid_data - received: %s - expected: %v", uuidBytes, uuid.String())
+	}
+
+	// select uuids into a uuid type with its own sql.Scanner, like google/uuid UUID
+	var uuidScanner testScannerUUID
+	var timeUUIDScanner testScannerUUID
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select uuid_data, timeuuid_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "uuid").Scan(&uuidScanner, &timeUUIDScanner)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if gocql.UUID(uuidScanner) != uuid {
+		t.Fatalf("uuid_data - received: %v - expected: %v", gocql.UUID(uuidScanner), uuid)
+	}
+	if gocql.UUID(timeUUIDScanner) != timeUUID {
+		t.Fatalf("timeuuid_data - received: %v - expected: %v", gocql.UUID(timeUUIDScanner), timeUUID)
+	}
+
+	// select uuids into gocql.UUID
+	var cqlUUID gocql.UUID
+	var cqlTimeUUID gocql.UUID
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select uuid_data, timeuuid_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "uuid").Scan((*UUID)(&cqlUUID), (*UUID)(&cqlTimeUUID))
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if cqlUUID != uuid {
+		t.Fatalf("uuid_data - received: %v - expected: %v", cqlUUID, uuid)
+	}
+	if cqlTimeUUID != timeUUID {
+		t.Fatalf("timeuuid_data - received: %v - expected: %v", cqlTimeUUID, timeUUID)
+	}
+
+	testRunGoVersionSubtests(t)
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlBigNumber(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// insert decimal and varint beyond int64 range
+	large := "123456789012345678901234567890"
+	varint, _ := new(big.Int).SetString(large, 10)
+	decimal, _ := new(inf.Dec).SetString("12345678901234567890.123456789")
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	result, err := db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, decimal_data, varint_data) values (?, ?, ?)", "big number", decimal, varint)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+
+	// select into string
+	var decimalString string
+	var varintString string
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select decimal_data, varint_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "big number").Scan(&decimalString, &varintString)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if decimalString != decimal.String() {
+		t.Fatalf("decimal_data - received: %v - expected: %v", decimalString, decimal.String())
+	}
+	if varintString != large {
+		t.Fatalf("varint_data - received: %v - expected: %v", varintString, large)
+	}
+
+	// select into inf.Dec and big.Int
+	decimalData := new(inf.Dec)
+	varintData := new(big.Int)
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select decimal_data, varint_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "big number").Scan((*Decimal)(decimalData), (*Varint)(varintData))
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if decimalData.Cmp(decimal) != 0 {
+		t.Fatalf("decimal_data - received: %v - expected: %v", decimalData, decimal)
+	}
+	if varintData.Cmp(varint) != 0 {
+		t.Fatalf("varint_data - received: %v - expected: %v", varintData, varint)
+	}
+
+	// select into big.Float
+	decimalFloat := new(big.Float)
+	varintFloat := new(big.Float)
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select decimal_data, varint_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "big number").Scan((*BigFloat)(decimalFloat), (*BigFloat)(varintFloat))
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	expectedFloat, _ := new(big.Float).SetString(decimal.String())
+	if decimalFloat.Cmp(expectedFloat) != 0 {
+		t.Fatalf("decimal_data - received: %v - expected: %v", decimalFloat, expectedFloat)
+	}
+	if varintFloat.Cmp(new(big.Float).SetInt(varint)) != 0 {
+		t.Fatalf("varint_data - received: %v - expected: %v", varintFloat, varint)
+	}
+
+	// select varint beyond int64 range into int64
+	var varintInt64 int64
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select varint_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "big number").Scan(&varintInt64)
+	cancel()
+	if err == nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, "value out of range")
+	}
+
+	// select null into inf.Dec and big.Int, as the zero value
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, decimal_data, varint_data) values (?, ?, ?)", "big number null", nil, nil)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	if result == nil {
+		t.Fatal("result is nil")
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select decimal_data, varint_data from "+KeyspaceName+"."+TableName+" where text_data = ?", "big number null").Scan((*Decimal)(decimalData), (*Varint)(varintData))
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if decimalData.Sign() != 0 {
+		t.Fatalf("decimal_data - received: %v - expected: %v", decimalData, 0)
+	}
+	if varintData.Sign() != 0 {
+		t.Fatalf("varint_data - received: %v - expected: %v", varintData, 0)
+	}
+
+	testRunGoVersionSubtests(t)
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlInet(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	tests := []struct {
+		key string
+		ip  net.IP
+	}{
+		{key: "inet ipv4", ip: net.ParseIP("192.168.1.10")},
+		{key: "inet ipv6", ip: net.ParseIP("2001:db8::68")},
+		{key: "inet null"},
+	}
+
+	for _, test := range tests {
+		// insert inet
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		var result sql.Result
+		if test.ip == nil {
+			result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data) values (?)", test.key)
+		} else {
+			result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, inet_data) values (?, ?)", test.key, test.ip)
+		}
+		cancel()
+		if err != nil {
+			t.Fatal("ExecContext error: ", err)
+		}
+		if result == nil {
+			t.Fatal("result is nil")
+		}
+
+		// select inet into IP
+		var ipScanner net.IP
+		ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+		err = db.QueryRowContext(ctx, "select inet_data from "+KeyspaceName+"."+TableName+" where text_data = ?", test.key).Scan((*IP)(&ipScanner))
+		cancel()
+		if err != nil {
+			t.Fatal("Scan error: ", err)
+		}
+		if test.ip == nil {
+			if ipScanner != nil {
+				t.Fatalf("inet_data - received: %v - expected: %v", ipScanner, nil)
+			}
+			continue
+		}
+		if !ipScanner.Equal(test.ip) {
+			t.Fatalf("inet_data - received: %v - expected: %v", ipScanner, test.ip)
+		}
+
+		// select inet into net.IP and []byte
+		var ip net.IP
+		var ipBytes []byte
+		ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+		err = db.QueryRowContext(ctx, "select inet_data, inet_data from "+KeyspaceName+"."+TableName+" where text_data = ?", test.key).Scan(&ip, &ipBytes)
+		cancel()
+		if err != nil {
+			t.Fatal("Scan error: ", err)
+		}
+		if !ip.Equal(test.ip) {
+			t.Fatalf("inet_data - received: %v - expected: %v", ip, test.ip)
+		}
+		expectedBytes := []byte(test.ip.To4())
+		if expectedBytes == nil {
+			expectedBytes = []byte(test.ip)
+		}
+		if !reflect.DeepEqual(ipBytes, expectedBytes) {
+			t.Fatalf("inet_data - received: %v - expected: %v", ipBytes, expectedBytes)
+		}
+	}
+
+	testRunGoVersionSubtests(t)
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlBlobWriter(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	large := strings.Repeat("0123456789abcdef", 128*1024)
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, string_data) values (?, ?)", "blob writer", large)
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+
+	var buffer bytes.Buffer
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select textAsBlob(string_data) from "+KeyspaceName+"."+TableName+" where text_data = ?", "blob writer").Scan(&BlobWriter{Writer: &buffer})
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	if buffer.String() != large {
+		t.Fatalf("blob - received: %v bytes - expected: %v bytes", buffer.Len(), len(large))
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlNull(t *testing.T) {
+	if DisableDestructiveTests {
+		t.SkipNow()
+	}
+
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	tests := []struct {
+		key         string
+		values      []interface{}
+		stringData  sql.NullString
+		intData     sql.NullInt64
+		booleanData sql.NullBool
+		bigintData  sql.NullInt64
+		doubleData  sql.NullFloat64
+	}{
+		{key: "null all"},
+		{key: "null none", values: []interface{}{"", 1, false, int64(-2), 3.5},
+			stringData: sql.NullString{String: "", Valid: true}, intData: sql.NullInt64{Int64: 1, Valid: true}, booleanData: sql.NullBool{Bool: false, Valid: true},
+			bigintData: sql.NullInt64{Int64: -2, Valid: true}, doubleData: sql.NullFloat64{Float64: 3.5, Valid: true}},
+		{key: "null some", values: []interface{}{"a", nil, true, nil, 0.0},
+			stringData: sql.NullString{String: "a", Valid: true}, booleanData: sql.NullBool{Bool: true, Valid: true},
+			doubleData: sql.NullFloat64{Float64: 0, Valid: true}},
+	}
+
+	for _, test := range tests {
+		// insert values
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		var result sql.Result
+		if test.values == nil {
+			result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data) values (?)", test.key)
+		} else {
+			result, err = db.ExecContext(ctx, "insert into "+KeyspaceName+"."+TableName+" (text_data, string_data, int_data, boolean_data, bigint_data, double_data) values (?, ?, ?, ?, ?, ?)",
+				append([]interface{}{test.key}, test.values...)...)
+		}
+		cancel()
+		if err != nil {
+			t.Fatal("ExecContext error: ", err)
+		}
+		if result == nil {
+			t.Fatal("result is nil")
+		}
+
+		// select into sql.Null types
+		var stringData sql.NullString
+		var intData sql.NullInt64
+		var booleanData sql.NullBool
+		var bigintData sql.NullInt64
+		var doubleData sql.NullFloat64
+		ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+		err = db.QueryRowContext(ctx, "select string_data, int_data, boolean_data, bigint_data, double_data from "+KeyspaceName+"."+TableName+" where text_data = ?", test.key).
+			Scan(&stringData, &intData, &booleanData, &bigintData, &doubleData)
+		cancel()
+		if err != nil {
+			t.Fatal("Scan error: ", err)
+		}
+		if stringData != test.stringData {
+			t.Fatalf("string_data %v - received: %v - expected: %v", test.key, stringData, test.stringData)
+		}
+		if intData != test.intData {
+			t.Fatalf("int_data %v - received: %v - expected: %v", test.key, intData, test.intData)
+		}
+		if booleanData != test.booleanData {
+			t.Fatalf("boolean_data %v - received: %v - expected: %v", test.key, booleanData, test.booleanData)
+		}
+		if bigintData != test.bigintData {
+			t.Fatalf("bigint_data %v - received: %v - expected: %v", test.key, bigintData, test.bigintData)
+		}
+		if doubleData != test.doubleData {
+			t.Fatalf("double_data %v - received: %v - expected: %v", test.key, doubleData, test.doubleData)
+		}
+
+		// select into interface
+		var stringInterface interface{}
+		var intInterface interface{}
+		ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+		err = db.QueryRowContext(ctx, "select string_data, int_data from "+KeyspaceName+"."+TableName+" where text_data = ?", test.key).Scan(&stringInterface, &intInterface)
+		cancel()
+		if err != nil {
+			t.Fatal("Scan error: ", err)
+		}
+		if (stringInterface == nil) == test.stringData.Valid {
+			t.Fatalf("string_data %v - received: %v - expected: %v", test.key, stringInterface, test.stringData)
+		}
+		if (intInterface == nil) == test.intData.Valid {
+			t.Fatalf("int_data %v - received: %v - expected: %v", test.key, intInterface, test.intData)
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+func TestSqlTracing(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	// query without tracing
+	var releaseVersion string
+	ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(ctx, "select release_version from system.local").Scan(&releaseVersion)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+
+	// query with tracing
+	sliceTracer := &SliceTracer{}
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	err = db.QueryRowContext(WithTracing(ctx, sliceTracer), "select release_version from system.local").Scan(&releaseVersion)
+	cancel()
+	if err != nil {
+		t.Fatal("Scan error: ", err)
+	}
+	traceIDs := sliceTracer.TraceIDs()
+	if len(traceIDs) != 1 {
+		t.Fatalf("TraceIDs - received: %v - expected: %v", len(traceIDs), 1)
+	}
+
+	// exec with tracing
+	ctx, cancel = context.WithTimeout(context.Background(), TimeoutValid)
+	_, err = db.ExecContext(WithTracing(ctx, sliceTracer), "select release_version from system.local")
+	cancel()
+	if err != nil {
+		t.Fatal("ExecContext error: ", err)
+	}
+	traceIDs = sliceTracer.TraceIDs()
+	if len(traceIDs) != 2 {
+		t.Fatalf("TraceIDs - received: %v - expected: %v", len(traceIDs), 2)
+	}
+
+	// trace events of both queries
+	err = sliceTracer.Err()
+	if err != nil {
+		t.Fatal("Err error: ", err)
+	}
+	events := sliceTracer.Events()
+	for _, traceID := range traceIDs {
+		count := 0
+		for _, event := range events {
+			if event.TraceID != traceID {
+				continue
+			}
+			count++
+			if event.Activity == "" {
+				t.Fatalf("Activity - received: %v - expected: %v", event.Activity, "not empty")
+			}
+			if event.Source == nil {
+				t.Fatalf("Source - received: %v - expected: %v", event.Source, "not nil")
+			}
+			if event.EventID.Timestamp() == 0 {
+				t.Fatalf("EventID - received: %v - expected: %v", event.EventID, "time uuid")
+			}
+		}
+		if count == 0 {
+			t.Fatalf("events for trace %v - received: %v - expected: %v", traceID, count, "more than 0")
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
+type testSpeculativeExecution struct {
+	mutex    sync.Mutex
+	attempts int
+}
+
+func (policy *testSpeculativeExecution) Attempts() int {
+	policy.mutex.Lock()
+	policy.attempts++
+	policy.mutex.Unlock()
+	return 1
+}
+
+func (policy *testSpeculativeExecution) Delay() time.Duration {
+	return time.Second
+}
+
+func TestSqlSpeculativeExecution(t *testing.T) {
+	openString := TestHostValid + "?timeout=10s&connectTimeout=10s"
+	if EnableAuthentication {
+		openString += "&username=" + Username + "&password=" + Password
+	}
+
+	db, err := sql.Open("cql", openString)
+	if err != nil {
+		t.Fatal("Open error: ", err)
+	}
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+
+	tests := []struct {
+		idempotent bool
+		expected   bool
+	}{
+		{idempotent: false, expected: false},
+		{idempotent: true, expected: true},
+	}
+
+	for _, test := range tests {
+		policy := &testSpeculativeExecution{}
+		var releaseVersion string
+		ctx, cancel := context.WithTimeout(context.Background(), TimeoutValid)
+		ctx = WithIdempotent(WithSpeculativeExecution(ctx, policy), test.idempotent)
+		err = db.QueryRowContext(ctx, "select release_version from system.local").Scan(&releaseVersion)
+		cancel()
+		if err != nil {
+			t.Fatal("Scan error: ", err)
+		}
+		policy.mutex.Lock()
+		used := policy.attempts > 0
+		policy.mutex.Unlock()
+		if used != test.expected {
+			t.Fatalf("speculative execution used for idempotent %v - received: %v - expected: %v", test.idempotent, used, test.expected)
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatal("Close error: ", err)
+	}
+}
+
 func TestSqlSelectLoop(t *testing.T) {
 	if DisableDestructiveTests {
 		t.SkipNow()
@@ -23,12 +23,21 @@ var (
 	DisableDestructiveTests   bool
 	KeyspaceName              = "cqltest"
 	TableName                 = "cqltest_"
+	CounterTableName          = "cqltest_counter"
 	EnableAuthentication      bool
 	Username                  string
 	Password                  string
 	TestTimeNow               time.Time
+	// TestGoVersionSubtests are subtests in build tagged test files by the name of the test that runs them
+	TestGoVersionSubtests = make(map[string][]testGoVersionSubtest)
 )
 
+// testGoVersionSubtest is a subtest that needs a newer Go version, added by the init of a build tagged test file
+type testGoVersionSubtest struct {
+	name string
+	test func(t *testing.T)
+}
+
 func TestMain(m *testing.M) {
 	code := setupForTesting()
 	if code != 0 {
@@ -73,6 +82,18 @@ func setupForTesting() int {
 	return 0
 }
 
+// testAddGoVersionSubtest adds test as a subtest of the test named parent
+func testAddGoVersionSubtest(parent string, name string, test func(t *testing.T)) {
+	TestGoVersionSubtests[parent] = append(TestGoVersionSubtests[parent], testGoVersionSubtest{name: name, test: test})
+}
+
+// testRunGoVersionSubtests runs the subtests added for t
+func testRunGoVersionSubtests(t *testing.T) {
+	for _, subtest := range TestGoVersionSubtests[t.Name()] {
+		t.Run(subtest.name, subtest.test)
+	}
+}
+
 func TestDriverOpen(t *testing.T) {
 	CqlDriver.Logger = nil
 	conn, err := CqlDriver.Open("")
@@ -2,15 +2,137 @@ package cql
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"io"
 	"log"
+	"math/big"
+	"net"
 	"os"
+	"reflect"
+	"sync"
+	"time"
 
 	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
 )
 
 type (
+	// BatchStatement is a statement with its bind values to be executed in a batch
+	BatchStatement struct {
+		Statement string
+		Values    []interface{}
+	}
+
+	// Batcher is implemented by the driver connection to execute statements in a batch.
+	// With Go 1.13 or later the driver connection can be obtained by sql.Conn Raw.
+	Batcher interface {
+		BatchExec(ctx context.Context, batchType gocql.BatchType, statements []BatchStatement) error
+	}
+
+	// ConsistencySetter is implemented by the driver connection to override the consistency of queries
+	// executed on the connection until database/sql resets the connection session.
+	// WithConsistency overrides it for queries executed with the context.
+	// With Go 1.13 or later the driver connection can be obtained by sql.Conn Raw.
+	ConsistencySetter interface {
+		SetConsistency(consistency gocql.Consistency)
+	}
+
+	// CASExecer is implemented by the driver connection to execute lightweight transactions,
+	// insert, update, and delete statements with an if clause.
+	// With Go 1.13 or later the driver connection can be obtained by sql.Conn Raw.
+	CASExecer interface {
+		ExecCAS(ctx context.Context, statement string, values ...interface{}) (applied bool, existing map[string]interface{}, err error)
+	}
+
+	// SchemaAgreementAwaiter is implemented by the driver connection to wait for all nodes to agree on the schema version,
+	// up to the ClusterConfig MaxWaitSchemaAgreement or the context deadline. See AwaitSchemaAgreement.
+	// With Go 1.13 or later the driver connection can be obtained by sql.Conn Raw.
+	SchemaAgreementAwaiter interface {
+		AwaitSchemaAgreement(ctx context.Context) error
+	}
+
+	// DsePlainTextAuthenticator authenticates with username and password to DataStax Enterprise DseAuthenticator
+	// using the SASL PLAIN mechanism. Other authenticators are sent the username and password like gocql PasswordAuthenticator.
+	// Set it with the config string keys username, password, and dseAuth=plainText, or with WithAuthenticator.
+	DsePlainTextAuthenticator struct {
+		Username string
+		Password string
+	}
+
+	// dsePlainTextChallenge answers the DseAuthenticator challenge after the PLAIN mechanism is sent
+	dsePlainTextChallenge DsePlainTextAuthenticator
+
+	// Tuple scans a tuple column into its elements.
+	// Each element is a pointer, the number of elements must match the tuple.
+	// To scan, use rows.Scan(cql.Tuple{new(string), new(int32), new(bool)})
+	Tuple []interface{}
+
+	// UUID scans a uuid or timeuuid column, or a string or 16 byte uuid, into a 16 byte uuid type like gocql.UUID.
+	// To scan, use rows.Scan((*cql.UUID)(&id)) where id is a gocql.UUID. With Go 1.27 or later a gocql.UUID can be scanned into as is.
+	UUID gocql.UUID
+
+	// Varint scans a varint column into a big.Int, a null varint is zero.
+	// To scan, use rows.Scan((*cql.Varint)(x)) where x is a *big.Int. With Go 1.27 or later a *big.Int can be scanned into as is.
+	Varint big.Int
+
+	// Decimal scans a decimal column into an inf.Dec, a null decimal is zero.
+	// To scan, use rows.Scan((*cql.Decimal)(x)) where x is a *inf.Dec. With Go 1.27 or later a *inf.Dec can be scanned into as is.
+	Decimal inf.Dec
+
+	// BigFloat scans a decimal or varint column into a big.Float.
+	// To scan, use rows.Scan((*cql.BigFloat)(x)) where x is a *big.Float
+	BigFloat big.Float
+
+	// IP scans an inet column, or a string of an ip, into a net.IP, a null inet is a nil net.IP.
+	// Inet columns scan into a *net.IP as is, IP also scans a null inet before Go 1.27.
+	// To scan, use rows.Scan((*cql.IP)(&ip)) where ip is a net.IP
+	IP net.IP
+
+	// BlobWriter scans a blob column into an io.Writer, the value is written without copying it into a []byte.
+	// gocql reads the whole value into memory before it is written, so it is not streamed from the server.
+	// To scan, use rows.Scan(&cql.BlobWriter{Writer: w})
+	BlobWriter struct {
+		Writer io.Writer
+	}
+
+	// QueryStats are the host a query was sent to, the number of attempts, and the average latency per attempt,
+	// see WithQueryStats. Host is nil if the query was not sent.
+	QueryStats struct {
+		Host     *gocql.HostInfo
+		Attempts int
+		Latency  time.Duration
+	}
+
+	// SliceTracer is a gocql Tracer that records the trace ids and trace events of traced queries into slices.
+	// Used with WithTracing, the trace events are selected from system_traces.events after each traced query,
+	// waiting up to a second for Cassandra to finish writing the trace. Used as a session tracer only the trace ids are recorded.
+	// The zero value is ready to use. It is meant for tests and debugging, not for production load.
+	SliceTracer struct {
+		mutex    sync.Mutex
+		traceIDs []gocql.UUID
+		events   []TraceEvent
+		err      error
+	}
+
+	// TraceEvent is an event of a query trace, a row of system_traces.events
+	TraceEvent struct {
+		TraceID       gocql.UUID
+		EventID       gocql.UUID
+		Activity      string
+		Source        net.IP
+		SourceElapsed time.Duration
+		Thread        string
+	}
+
+	// sliceSessionTracer is a SliceTracer with the session to select the trace events with
+	sliceSessionTracer struct {
+		sliceTracer *SliceTracer
+		session     *gocql.Session
+	}
+
 	// CqlDriverStruct is the sql driver
 	CqlDriverStruct struct {
 		// Logger is used to log connection ping errors
@@ -24,14 +146,76 @@ type (
 		// ClusterConfig is used for changing config options
 		// https://godoc.org/github.com/gocql/gocql#ClusterConfig
 		ClusterConfig *gocql.ClusterConfig
+		// session is the borrowed session set by NewConnectorFromSession
+		session *gocql.Session
+		// disablePrepareCache is set by WithDisablePrepareCache
+		disablePrepareCache bool
+		// readConsistency and writeConsistency are set by WithDefaultReadConsistency and WithDefaultWriteConsistency
+		readConsistency  *gocql.Consistency
+		writeConsistency *gocql.Consistency
+		// eagerPoolFill is set by WithEagerPoolFill
+		eagerPoolFill bool
 	}
 
+	// DSN is a parsed config string, see ParseDSN.
+	// The exported fields can be changed before calling ClusterConfig, a DSN can also be filled in without ParseDSN.
+	DSN struct {
+		Hosts             []string
+		Port              int
+		Keyspace          string
+		Consistency       gocql.Consistency
+		SerialConsistency gocql.SerialConsistency
+		Timeout           time.Duration
+		ConnectTimeout    time.Duration
+		WriteTimeout      time.Duration
+		NumConns          int
+		PageSize          int
+		ProtoVersion      int
+		Username          string
+		Password          string
+		// clusterConfig has the other settings of the config string
+		clusterConfig *gocql.ClusterConfig
+	}
+
+	// ConfigBuilder builds a config string, see NewConfigBuilder
+	ConfigBuilder struct {
+		clusterConfig *gocql.ClusterConfig
+	}
+
+	// ConnectorOption sets an option on a connector, see NewConnectorFromClusterConfig
+	ConnectorOption func(cqlConnector *CqlConnector)
+
 	cqlConnStruct struct {
 		logger        *log.Logger
 		clusterConfig *gocql.ClusterConfig
 		context       context.Context
 		session       *gocql.Session
 		pingQuery     *gocql.Query
+		closed        bool
+		// consistency is set by SetConsistency and cleared by ResetSession
+		consistency *gocql.Consistency
+		// invalid is set when an operation returns a fatal connection error
+		invalid bool
+		// borrowed is set when the session is owned by the caller of NewConnectorFromSession
+		borrowed bool
+		// disablePrepareCache is set from the connector, statements without bind markers are not prepared
+		disablePrepareCache bool
+		// readConsistency and writeConsistency are set from the connector, the default consistency of select and mutation statements
+		readConsistency  *gocql.Consistency
+		writeConsistency *gocql.Consistency
+		// eagerPoolFill is set from the connector, Ping waits for the connection pools to fill when it creates a session
+		eagerPoolFill bool
+		// tx is set by BeginTx, statements executed while it is set are added to its batch
+		tx *cqlTxStruct
+	}
+
+	// poolFillObserver counts the connections made by a session being created, see WithEagerPoolFill
+	poolFillObserver struct {
+		observer  gocql.ConnectObserver
+		mutex     sync.Mutex
+		hosts     map[string]struct{}
+		connected int
+		changed   chan struct{}
 	}
 
 	// CqlStmt is the sql driver statement
@@ -40,19 +224,74 @@ type (
 		// https://godoc.org/github.com/gocql/gocql#Query
 		// This will only work if Go sql every gives access to the driver
 		CqlQuery *gocql.Query
+		session  *gocql.Session
+		conn     *cqlConnStruct
 	}
 
 	cqlResultStruct struct {
 	}
 
+	// cqlTxStruct is a transaction that executes its statements in a batch on Commit
+	cqlTxStruct struct {
+		conn       *cqlConnStruct
+		context    context.Context
+		batchType  gocql.BatchType
+		statements []BatchStatement
+	}
+
 	cqlRowsStruct struct {
-		iter    *gocql.Iter
-		columns []string
+		iter       *gocql.Iter
+		columns    []string
+		columnInfo []gocql.ColumnInfo
+		session    *gocql.Session
+		conn       *cqlConnStruct
+		// context and statements are used by NextResultSet to query the next statements of a multiple statement query
+		context    context.Context
+		statements []string
+		// row is the row read by NextRow for ScanColumn
+		row []driver.Value
 	}
 
 	converter struct{}
+
+	// hostSelectionPolicy wraps a gocql HostSelectionPolicy built from a config string
+	// and keeps its settings so it can be converted back to a config string
+	hostSelectionPolicy struct {
+		gocql.HostSelectionPolicy
+		name            string
+		localDC         string
+		shuffleReplicas bool
+		allowRemoteDCs  bool
+	}
+
+	// addressTranslator translates internal addresses to external addresses
+	// and keeps them so it can be converted back to a config string
+	addressTranslator struct {
+		internal []net.IP
+		external []net.IP
+	}
+
+	// connectLimitDialer limits the number of connections being dialed at the same time by the sessions of a ClusterConfig
+	// and keeps the limit so it can be converted back to a config string
+	connectLimitDialer struct {
+		dialer gocql.Dialer
+		limit  int
+		mutex  sync.Mutex
+		// semaphore is created on the first dial
+		semaphore chan struct{}
+	}
+
+	// hostFilter filters hosts by data centre and white list
+	// and keeps them so it can be converted back to a config string
+	hostFilter struct {
+		dataCentres []string
+		whiteList   []net.IP
+	}
 )
 
+// maxCollectionDepth is the most nested collections scanned into the collection scan type, see elementScanType
+const maxCollectionDepth = 16
+
 var (
 	// ErrNotSupported is returned for any method that is not supported
 	ErrNotSupported = fmt.Errorf("not supported")
@@ -61,10 +300,34 @@ var (
 	// ErrQueryIsNil is returned when a query is nil
 	ErrQueryIsNil = fmt.Errorf("query is nil")
 	// ErrNamedValuesNotSupported is returned when values are named. Named values are not supported.
+	// Deprecated: named values are supported, use ErrNamedValuesMixed instead.
 	ErrNamedValuesNotSupported = fmt.Errorf("named values not supported")
+	// ErrNamedValuesMixed is returned when named and positional values are used together
+	ErrNamedValuesMixed = fmt.Errorf("named and positional values can not be mixed")
+	// ErrBatchIsEmpty is returned when a batch has no statements
+	ErrBatchIsEmpty = fmt.Errorf("batch is empty")
+	// ErrNotLightweightTransaction is returned by ExecCAS when the statement result has no [applied] column
+	ErrNotLightweightTransaction = fmt.Errorf("not a lightweight transaction")
+	// ErrCustomPayloadNotSupported is returned when a custom payload is used with a protocol version lower than 4
+	ErrCustomPayloadNotSupported = fmt.Errorf("custom payload requires protocol version 4 or later")
+	// ErrWithoutPagingPageSize is returned when WithoutPaging and WithPageSize are used together
+	ErrWithoutPagingPageSize = fmt.Errorf("WithoutPaging can not be used with WithPageSize")
+	// ErrTxQueryNotSupported is returned for queries and statements that can not be in a batch during a transaction
+	ErrTxQueryNotSupported = fmt.Errorf("only insert, update, and delete statements can be used in a transaction")
+	// ErrMultipleStatementsValues is returned when values are used with a query of multiple statements
+	ErrMultipleStatementsValues = fmt.Errorf("values can not be used with multiple statements")
 	// ErrOrdinalOutOfRange is returned when values ordinal is out of range
 	ErrOrdinalOutOfRange = fmt.Errorf("ordinal out of range")
 
+	// interfaceType is the reflect.Type of interface{}
+	interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	// uuidScanType is the scan type of uuid and timeuuid columns, gocql.UUID when ScanColumn scans them into it
+	uuidScanType = reflect.TypeOf("")
+
+	// udtTypes maps keyspace.name of user defined types to the Go types registered by RegisterUDT
+	udtTypes      = make(map[string]reflect.Type)
+	udtTypesMutex sync.RWMutex
+
 	// CqlDriver is the sql driver
 	CqlDriver = &CqlDriverStruct{
 		Logger: log.New(os.Stderr, "cql ", log.Ldate|log.Ltime|log.LUTC|log.Llongfile),
@@ -97,6 +360,33 @@ var DbConsistency = map[gocql.Consistency]string{
 	gocql.LocalOne:    "localOne",
 }
 
+// DbSerialConsistencyLevels maps string to gocql serial consistency levels, local_serial is an alias of localSerial
+var DbSerialConsistencyLevels = map[string]gocql.SerialConsistency{
+	"serial":       gocql.Serial,
+	"localSerial":  gocql.LocalSerial,
+	"local_serial": gocql.LocalSerial,
+}
+
+// DbSerialConsistency maps gocql serial consistency levels to string
+var DbSerialConsistency = map[gocql.SerialConsistency]string{
+	gocql.Serial:      "serial",
+	gocql.LocalSerial: "localSerial",
+}
+
+// DbSslVersions maps string to tls versions
+var DbSslVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+}
+
+// DbSslVersion maps tls versions to string
+var DbSslVersion = map[uint16]string{
+	tls.VersionTLS10: "1.0",
+	tls.VersionTLS11: "1.1",
+	tls.VersionTLS12: "1.2",
+}
+
 func init() {
 	sql.Register("cql", CqlDriver)
 }
@@ -1,11 +1,13 @@
 package cql
 
-// LastInsertId not supported
+// LastInsertId not supported, CQL has no auto increment ids.
+// Always returns -1 and ErrNotSupported.
 func (cqlResult cqlResultStruct) LastInsertId() (int64, error) {
 	return -1, ErrNotSupported
 }
 
-// RowsAffected not supported
+// RowsAffected not supported, CQL does not report the number of rows affected.
+// Always returns -1 and ErrNotSupported.
 func (cqlResult cqlResultStruct) RowsAffected() (int64, error) {
 	return -1, ErrNotSupported
 }
@@ -0,0 +1,26 @@
+package cql
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	var result driver.Result = cqlResultStruct{}
+
+	num, err := result.LastInsertId()
+	if err == nil || err != ErrNotSupported {
+		t.Fatalf("LastInsertId error - received: %v - expected: %v ", err, ErrNotSupported)
+	}
+	if num != -1 {
+		t.Fatalf("LastInsertId - received: %v - expected: %v ", num, -1)
+	}
+
+	num, err = result.RowsAffected()
+	if err == nil || err != ErrNotSupported {
+		t.Fatalf("RowsAffected error - received: %v - expected: %v ", err, ErrNotSupported)
+	}
+	if num != -1 {
+		t.Fatalf("RowsAffected - received: %v - expected: %v ", num, -1)
+	}
+}
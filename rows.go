@@ -1,18 +1,26 @@
 package cql
 
 import (
+	"context"
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"reflect"
+
+	"github.com/gocql/gocql"
 )
 
-// Close the rows
+// Close the rows, gocql ErrNotFound is not an error, the rows were empty
 func (cqlRows *cqlRowsStruct) Close() error {
 	if cqlRows.iter == nil {
 		return nil
 	}
 	err := cqlRows.iter.Close()
 	cqlRows.iter = nil
+	if err == gocql.ErrNotFound {
+		return nil
+	}
+	cqlRows.conn.checkFatalError(err)
 	return err
 }
 
@@ -21,7 +29,100 @@ func (cqlRows *cqlRowsStruct) Columns() []string {
 	return cqlRows.columns
 }
 
-// Next rows
+// ColumnTypeDatabaseTypeName returns the CQL type name of a column, like text, int, or list<int>
+func (cqlRows *cqlRowsStruct) ColumnTypeDatabaseTypeName(index int) string {
+	if index < 0 || index >= len(cqlRows.columnInfo) {
+		return ""
+	}
+	return typeInfoToString(cqlRows.columnInfo[index].TypeInfo)
+}
+
+// ColumnTypeScanType returns the Go type suitable for scanning a column into
+func (cqlRows *cqlRowsStruct) ColumnTypeScanType(index int) reflect.Type {
+	if index < 0 || index >= len(cqlRows.columnInfo) {
+		return interfaceType
+	}
+	return typeInfoToScanType(cqlRows.columnInfo[index].TypeInfo)
+}
+
+// ColumnTypeNullable returns false for primary key columns and true for other columns.
+// ok is false when the table metadata for the column is not available.
+func (cqlRows *cqlRowsStruct) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if index < 0 || index >= len(cqlRows.columnInfo) || cqlRows.session == nil {
+		return true, false
+	}
+	columnInfo := cqlRows.columnInfo[index]
+
+	keyspaceMetadata, err := cqlRows.session.KeyspaceMetadata(columnInfo.Keyspace)
+	if err != nil {
+		return true, false
+	}
+	tableMetadata, ok := keyspaceMetadata.Tables[columnInfo.Table]
+	if !ok {
+		return true, false
+	}
+	columnMetadata, ok := tableMetadata.Columns[columnInfo.Name]
+	if !ok {
+		return true, false
+	}
+
+	switch columnMetadata.Kind {
+	case gocql.ColumnPartitionKey, gocql.ColumnClusteringKey:
+		return false, true
+	}
+	return true, true
+}
+
+// HasNextResultSet returns true when a query of multiple statements has more statements
+func (cqlRows *cqlRowsStruct) HasNextResultSet() bool {
+	return len(cqlRows.statements) > 0
+}
+
+// NextResultSet closes the current result set and queries the next statement.
+// Returns io.EOF when there are no more statements.
+func (cqlRows *cqlRowsStruct) NextResultSet() error {
+	if len(cqlRows.statements) < 1 {
+		return io.EOF
+	}
+	err := cqlRows.Close()
+	if err != nil {
+		cqlRows.statements = nil
+		return err
+	}
+
+	cqlStmt := &CqlStmt{
+		CqlQuery: cqlRows.conn.applyOverrides(cqlRows.session.Query(cqlRows.conn.queryStatement(cqlRows.statements[0]))),
+		session:  cqlRows.session,
+		conn:     cqlRows.conn,
+	}
+	cqlRows.statements = cqlRows.statements[1:]
+	rows, err := cqlStmt.queryContext(cqlRows.context, nil)
+	if err != nil {
+		cqlRows.statements = nil
+		return err
+	}
+
+	nextRows := rows.(*cqlRowsStruct)
+	cqlRows.iter = nextRows.iter
+	cqlRows.columns = nextRows.columns
+	cqlRows.columnInfo = nextRows.columnInfo
+	return nil
+}
+
+// Next rows.
+// Uuid and timeuuid columns are strings in canonical form, so they scan into *string, *[]byte, and uuid types with an sql.Scanner,
+// like google/uuid UUID. Use cql.UUID to scan into a gocql.UUID, with Go 1.27 or later it also scans as is, see ScanColumn.
+// Decimal and varint columns are strings in canonical form, not the gocql *inf.Dec and *big.Int they used to be.
+// Use cql.Decimal and cql.Varint to scan into an inf.Dec and a big.Int, with Go 1.27 or later they also scan as is, see ScanColumn.
+// Inet columns are net.IP, so they scan into *net.IP and *[]byte gets the address bytes.
+// With Go 1.27 or later they also scan into *string and sql.Scanner types as text, see ScanColumn.
+// Timestamp and date columns are time.Time in UTC, date columns at midnight.
+// Null columns are nil, so they scan into sql.NullString, sql.NullInt64, sql.NullBool, and others with Valid false.
+// Null timestamp and date columns are nil too, scan them into sql.NullTime.
+// With Go 1.27 or later they also scan into a *time.Time as the zero time, see ScanColumn.
+// Null list and set columns are empty slices, as CQL returns empty lists and sets as null.
+// Null map columns are nil maps.
+// Returns io.EOF when there are no more rows, including for gocql ErrNotFound, so QueryRow returns sql.ErrNoRows.
 func (cqlRows *cqlRowsStruct) Next(dest []driver.Value) error {
 	if cqlRows.iter == nil {
 		return io.EOF
@@ -29,6 +130,13 @@ func (cqlRows *cqlRowsStruct) Next(dest []driver.Value) error {
 
 	rowData, err := cqlRows.iter.RowData()
 	if err != nil {
+		if err == gocql.ErrNotFound {
+			return io.EOF
+		}
+		cqlRows.conn.checkFatalError(err)
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			return err
+		}
 		return fmt.Errorf("RowData error: %v", err)
 	}
 	length := len(rowData.Values)
@@ -36,18 +144,68 @@ func (cqlRows *cqlRowsStruct) Next(dest []driver.Value) error {
 		return io.EOF
 	}
 
+	// tuple columns are expanded in rowData, one value per tuple element
+	collections := make([]int, 0, 1)
+	nullables := make([]bool, length)
+	index := 0
+	for i := 0; i < len(cqlRows.columnInfo) && index < length; i++ {
+		typeInfos := []gocql.TypeInfo{cqlRows.columnInfo[i].TypeInfo}
+		tupleTypeInfo, isTuple := cqlRows.columnInfo[i].TypeInfo.(gocql.TupleTypeInfo)
+		if isTuple {
+			typeInfos = tupleTypeInfo.Elems
+		}
+		for j := 0; j < len(typeInfos) && index < length; j++ {
+			if value := columnScanValue(typeInfos[j]); value != nil {
+				rowData.Values[index] = value
+				collections = append(collections, index)
+			} else if isTuple && typeInfos[j].Type() == gocql.TypeInt {
+				// tuple int elements are int32 to match the tuple scan type
+				rowData.Values[index] = new(int32)
+			} else if !isTuple && isNullableColumn(typeInfos[j]) {
+				// scan into a pointer to the value so gocql sets it to nil for null
+				rowData.Values[index] = reflect.New(reflect.TypeOf(rowData.Values[index])).Interface()
+				nullables[index] = true
+			}
+			index++
+		}
+	}
+
 	if !cqlRows.iter.Scan(rowData.Values...) {
 		return io.EOF
 	}
-
-	if len(dest) < length {
-		length = len(dest)
+	for i := 0; i < len(collections); i++ {
+		emptyCollection(rowData.Values[collections[i]])
 	}
-	for i := 0; i < length; i++ {
-		dest[i], err = interfaceToValue(rowData.Values[i])
+
+	// tuple columns are returned as []interface{} of the tuple elements
+	index = 0
+	for i := 0; i < len(dest) && index < length; i++ {
+		if i < len(cqlRows.columnInfo) {
+			if tupleTypeInfo, ok := cqlRows.columnInfo[i].TypeInfo.(gocql.TupleTypeInfo); ok {
+				tuple := make([]interface{}, len(tupleTypeInfo.Elems))
+				for j := 0; j < len(tuple) && index < length; j++ {
+					tuple[j], err = interfaceToValue(rowData.Values[index])
+					if err != nil {
+						return fmt.Errorf("interfaceToValue error: %v", err)
+					}
+					index++
+				}
+				dest[i] = tuple
+				continue
+			}
+		}
+		if nullables[index] {
+			dest[i], err = nullableToValue(rowData.Values[index])
+		} else {
+			dest[i], err = interfaceToValue(rowData.Values[index])
+		}
 		if err != nil {
 			return fmt.Errorf("interfaceToValue error: %v", err)
 		}
+		if i < len(cqlRows.columnInfo) {
+			dest[i] = columnValue(cqlRows.columnInfo[i].TypeInfo, dest[i])
+		}
+		index++
 	}
 
 	return nil
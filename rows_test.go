@@ -1,9 +1,18 @@
 package cql
 
 import (
+	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"io"
+	"math/big"
+	"net"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
 )
 
 func TestRowsColumns(t *testing.T) {
@@ -86,3 +95,702 @@ func TestRowsNext(t *testing.T) {
 		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
 	}
 }
+
+func TestRowsColumnTypeDatabaseTypeName(t *testing.T) {
+	conn, stmt, rows := testGetRowsHostValid(t, "select cql_version, tokens, host_id, broadcast_address, truncated_at from system.local")
+	if rows == nil {
+		t.Fatal("rows is nil")
+	}
+	rowsColumnType := rows.(driver.RowsColumnTypeDatabaseTypeName)
+
+	expected := []string{"text", "set<text>", "uuid", "inet", "map<uuid, blob>"}
+	for i := 0; i < len(expected); i++ {
+		name := rowsColumnType.ColumnTypeDatabaseTypeName(i)
+		if name != expected[i] {
+			t.Fatalf("ColumnTypeDatabaseTypeName %v - received: %v - expected: %v ", i, name, expected[i])
+		}
+	}
+	name := rowsColumnType.ColumnTypeDatabaseTypeName(len(expected))
+	if name != "" {
+		t.Fatalf("ColumnTypeDatabaseTypeName - received: %v - expected: %v ", name, "")
+	}
+
+	err := rows.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = stmt.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestTypeInfoToString(t *testing.T) {
+	nativeType := func(typ gocql.Type) gocql.NativeType {
+		return gocql.NewNativeType(4, typ, "")
+	}
+	tests := []struct {
+		info     gocql.TypeInfo
+		expected string
+	}{
+		{info: nil, expected: ""},
+		{info: nativeType(gocql.TypeInt), expected: "int"},
+		{info: nativeType(gocql.TypeText), expected: "text"},
+		{info: nativeType(gocql.TypeVarchar), expected: "text"},
+		{info: nativeType(gocql.TypeTimeUUID), expected: "timeuuid"},
+		{info: gocql.NewNativeType(4, gocql.TypeCustom, "org.apache.cassandra.db.marshal.DateType"), expected: "org.apache.cassandra.db.marshal.DateType"},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeInt)}, expected: "list<int>"},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeSet), Elem: nativeType(gocql.TypeVarchar)}, expected: "set<text>"},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeText), Elem: nativeType(gocql.TypeBigInt)}, expected: "map<text, bigint>"},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeText),
+			Elem: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeInt)}}, expected: "map<text, list<int>>"},
+		{info: gocql.TupleTypeInfo{NativeType: nativeType(gocql.TypeTuple), Elems: []gocql.TypeInfo{nativeType(gocql.TypeInt), nativeType(gocql.TypeText)}}, expected: "tuple<int, text>"},
+		{info: gocql.UDTTypeInfo{NativeType: nativeType(gocql.TypeUDT), KeySpace: "ks", Name: "address"}, expected: "address"},
+	}
+
+	for _, test := range tests {
+		name := typeInfoToString(test.info)
+		if name != test.expected {
+			t.Fatalf("typeInfoToString failed for: %v - received: %v - expected: %v", test.info, name, test.expected)
+		}
+	}
+}
+
+func TestRowsColumnTypeScanType(t *testing.T) {
+	conn, stmt, rows := testGetRowsHostValid(t, "select cql_version, tokens, host_id, truncated_at from system.local")
+	if rows == nil {
+		t.Fatal("rows is nil")
+	}
+	rowsColumnType := rows.(driver.RowsColumnTypeScanType)
+
+	expected := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf([]string{}), uuidScanType, reflect.TypeOf(map[gocql.UUID][]byte{})}
+	for i := 0; i < len(expected); i++ {
+		scanType := rowsColumnType.ColumnTypeScanType(i)
+		if scanType != expected[i] {
+			t.Fatalf("ColumnTypeScanType %v - received: %v - expected: %v ", i, scanType, expected[i])
+		}
+	}
+
+	err := rows.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = stmt.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestTypeInfoToScanType(t *testing.T) {
+	nativeType := func(typ gocql.Type) gocql.NativeType {
+		return gocql.NewNativeType(4, typ, "")
+	}
+	nestedListType := func(depth int, elem gocql.TypeInfo) gocql.TypeInfo {
+		for i := 0; i < depth; i++ {
+			elem = gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: elem}
+		}
+		return elem
+	}
+	nestedSliceType := func(depth int, elem reflect.Type) reflect.Type {
+		for i := 0; i < depth; i++ {
+			elem = reflect.SliceOf(elem)
+		}
+		return elem
+	}
+	tests := []struct {
+		info     gocql.TypeInfo
+		expected reflect.Type
+	}{
+		{info: nil, expected: interfaceType},
+		{info: nativeType(gocql.TypeInt), expected: reflect.TypeOf(int32(0))},
+		{info: nativeType(gocql.TypeBigInt), expected: reflect.TypeOf(int64(0))},
+		{info: nativeType(gocql.TypeText), expected: reflect.TypeOf("")},
+		{info: nativeType(gocql.TypeVarchar), expected: reflect.TypeOf("")},
+		{info: nativeType(gocql.TypeBoolean), expected: reflect.TypeOf(false)},
+		{info: nativeType(gocql.TypeTimestamp), expected: reflect.TypeOf(time.Time{})},
+		{info: nativeType(gocql.TypeDate), expected: reflect.TypeOf(time.Time{})},
+		{info: nativeType(gocql.TypeUUID), expected: uuidScanType},
+		{info: nativeType(gocql.TypeTimeUUID), expected: uuidScanType},
+		{info: nativeType(gocql.TypeInet), expected: reflect.TypeOf(net.IP{})},
+		{info: nativeType(gocql.TypeBlob), expected: reflect.TypeOf([]byte{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeBigInt)}, expected: reflect.TypeOf([]int64{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeInt)}, expected: reflect.TypeOf([]int32{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeText)}, expected: reflect.TypeOf([]string{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: gocql.NewNativeType(4, gocql.TypeCustom, "org.example.Custom")}, expected: interfaceType},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeSet), Elem: nativeType(gocql.TypeText)}, expected: reflect.TypeOf([]string{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeSet), Elem: nativeType(gocql.TypeInt)}, expected: reflect.TypeOf([]int32{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeSet), Elem: nativeType(gocql.TypeUUID)}, expected: reflect.TypeOf([]gocql.UUID{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeText), Elem: nativeType(gocql.TypeBoolean)}, expected: reflect.TypeOf(map[string]bool{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeText), Elem: nativeType(gocql.TypeInt)}, expected: reflect.TypeOf(map[string]int32{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeInt),
+			Elem: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeInt)}}, expected: reflect.TypeOf(map[int32][]int32{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeText),
+			Elem: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeInt)}}, expected: reflect.TypeOf(map[string][]int32{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeList),
+			Elem: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeText), Elem: nativeType(gocql.TypeInt)}}, expected: reflect.TypeOf([]map[string]int32{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeSet),
+			Elem: gocql.CollectionType{NativeType: nativeType(gocql.TypeList),
+				Elem: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap), Key: nativeType(gocql.TypeInt), Elem: nativeType(gocql.TypeUUID)}}}, expected: reflect.TypeOf([][]map[int32]gocql.UUID{})},
+		{info: gocql.CollectionType{NativeType: nativeType(gocql.TypeMap),
+			Key: gocql.CollectionType{NativeType: nativeType(gocql.TypeList), Elem: nativeType(gocql.TypeInt)}, Elem: nativeType(gocql.TypeText)}, expected: interfaceType},
+		{info: nestedListType(maxCollectionDepth, nativeType(gocql.TypeInt)), expected: nestedSliceType(maxCollectionDepth, reflect.TypeOf(int32(0)))},
+		{info: nestedListType(maxCollectionDepth+1, nativeType(gocql.TypeInt)), expected: nestedSliceType(maxCollectionDepth+1, reflect.TypeOf(int(0)))},
+		{info: gocql.NewNativeType(4, gocql.TypeCustom, "org.example.Custom"), expected: interfaceType},
+	}
+
+	for _, test := range tests {
+		scanType := typeInfoToScanType(test.info)
+		if scanType != test.expected {
+			t.Fatalf("typeInfoToScanType failed for: %v - received: %v - expected: %v", test.info, scanType, test.expected)
+		}
+	}
+}
+
+func TestRowsColumnTypeNullable(t *testing.T) {
+	conn, stmt, rows := testGetRowsHostValid(t, "select key, cql_version from system.local")
+	if rows == nil {
+		t.Fatal("rows is nil")
+	}
+	rowsColumnType := rows.(driver.RowsColumnTypeNullable)
+
+	// partition key
+	nullable, ok := rowsColumnType.ColumnTypeNullable(0)
+	if nullable || !ok {
+		t.Fatalf("ColumnTypeNullable - received: %v, %v - expected: %v, %v ", nullable, ok, false, true)
+	}
+
+	// regular column
+	nullable, ok = rowsColumnType.ColumnTypeNullable(1)
+	if !nullable || !ok {
+		t.Fatalf("ColumnTypeNullable - received: %v, %v - expected: %v, %v ", nullable, ok, true, true)
+	}
+
+	// unknown column
+	nullable, ok = rowsColumnType.ColumnTypeNullable(2)
+	if !nullable || ok {
+		t.Fatalf("ColumnTypeNullable - received: %v, %v - expected: %v, %v ", nullable, ok, true, false)
+	}
+
+	err := rows.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = stmt.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+	err = conn.Close()
+	if err != nil {
+		t.Fatalf("Close error - received: %v - expected: %v ", err, nil)
+	}
+}
+
+func TestTupleScan(t *testing.T) {
+	var text string
+	var number int64
+	var boolean bool
+	err := Tuple{&text, &number, &boolean}.Scan([]interface{}{"a", int32(1), true})
+	if err != nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+	}
+	if text != "a" || number != 1 || !boolean {
+		t.Fatalf("Scan - received: %v, %v, %v - expected: %v, %v, %v", text, number, boolean, "a", 1, true)
+	}
+
+	err = Tuple{&text, &number, &boolean}.Scan([]interface{}{nil, nil, nil})
+	if err != nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+	}
+	if text != "" || number != 0 || boolean {
+		t.Fatalf("Scan - received: %v, %v, %v - expected: %v, %v, %v", text, number, boolean, "", 0, false)
+	}
+
+	tests := []struct {
+		tuple    Tuple
+		src      interface{}
+		expected string
+	}{
+		{tuple: Tuple{&text}, src: "a", expected: "tuple source is not []interface{}: string"},
+		{tuple: Tuple{&text}, src: []interface{}{"a", 1}, expected: "tuple has 2 elements, destination has 1"},
+		{tuple: Tuple{&text, &number}, src: []interface{}{"a"}, expected: "tuple has 1 elements, destination has 2"},
+		{tuple: Tuple{text}, src: []interface{}{"a"}, expected: "tuple destination 0 is not a pointer"},
+		{tuple: Tuple{&number}, src: []interface{}{"a"}, expected: "tuple element 0 of type string can not be scanned into *int64"},
+		{tuple: Tuple{&text}, src: []interface{}{1}, expected: "tuple element 0 of type int can not be scanned into *string"},
+	}
+
+	for _, test := range tests {
+		err = test.tuple.Scan(test.src)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test.src, err, test.expected)
+		}
+	}
+}
+
+func TestRegisterUDT(t *testing.T) {
+	udtTypeInfo := gocql.UDTTypeInfo{NativeType: gocql.NewNativeType(4, gocql.TypeUDT, ""), KeySpace: "ks", Name: "register_test",
+		Elements: []gocql.UDTField{{Name: "a", Type: gocql.NewNativeType(4, gocql.TypeText, "")}}}
+
+	scanType := typeInfoToScanType(udtTypeInfo)
+	if scanType != reflect.TypeOf(map[string]interface{}{}) {
+		t.Fatalf("typeInfoToScanType - received: %v - expected: %v", scanType, reflect.TypeOf(map[string]interface{}{}))
+	}
+
+	err := RegisterUDT("ks", "register_test", struct{ A string }{})
+	expectedError := "value is not a pointer: struct { A string }"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("RegisterUDT error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = RegisterUDT("ks", "register_test", nil)
+	expectedError = "value is not a pointer: <nil>"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("RegisterUDT error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	err = RegisterUDT("ks", "register_test", &struct{ A string }{})
+	if err != nil {
+		t.Fatalf("RegisterUDT error - received: %v - expected: %v ", err, nil)
+	}
+	scanType = typeInfoToScanType(udtTypeInfo)
+	if scanType != reflect.TypeOf(struct{ A string }{}) {
+		t.Fatalf("typeInfoToScanType - received: %v - expected: %v", scanType, reflect.TypeOf(struct{ A string }{}))
+	}
+	value := columnScanValue(udtTypeInfo)
+	if _, ok := value.(*struct{ A string }); !ok {
+		t.Fatalf("columnScanValue - received: %T - expected: %T", value, &struct{ A string }{})
+	}
+}
+
+func TestUUIDScan(t *testing.T) {
+	cqlUUID, err := gocql.ParseUUID("4a3c8d2e-5f6b-4c7d-8e9f-0a1b2c3d4e5f")
+	if err != nil {
+		t.Fatalf("ParseUUID error - received: %v - expected: %v ", err, nil)
+	}
+
+	tests := []struct {
+		src      interface{}
+		expected gocql.UUID
+	}{
+		{src: nil, expected: gocql.UUID{}},
+		{src: "4a3c8d2e-5f6b-4c7d-8e9f-0a1b2c3d4e5f", expected: cqlUUID},
+		{src: cqlUUID.Bytes(), expected: cqlUUID},
+		{src: cqlUUID, expected: cqlUUID},
+	}
+
+	for _, test := range tests {
+		uuid := cqlUUID
+		err = (*UUID)(&uuid).Scan(test.src)
+		if err != nil {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test.src, err, nil)
+		}
+		if uuid != test.expected {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test.src, uuid, test.expected)
+		}
+	}
+
+	var uuid gocql.UUID
+	err = (*UUID)(&uuid).Scan(1)
+	expectedError := "uuid source is not a string: int"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = (*UUID)(&uuid).Scan("a")
+	if err == nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, "invalid UUID")
+	}
+
+	for _, cqlType := range []gocql.Type{gocql.TypeUUID, gocql.TypeTimeUUID} {
+		value := columnValue(gocql.NewNativeType(4, cqlType, ""), cqlUUID)
+		if value != cqlUUID.String() {
+			t.Fatalf("columnValue - received: %#v - expected: %#v", value, cqlUUID.String())
+		}
+	}
+}
+
+func TestBigNumberScan(t *testing.T) {
+	large := "123456789012345678901234567890"
+
+	var varint big.Int
+	err := (*Varint)(&varint).Scan(large)
+	if err != nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+	}
+	if varint.String() != large {
+		t.Fatalf("Scan - received: %v - expected: %v", varint.String(), large)
+	}
+	err = (*Varint)(&varint).Scan(nil)
+	if err != nil || varint.Sign() != 0 {
+		t.Fatalf("Scan - received: %v, %v - expected: %v, %v", varint.String(), err, 0, nil)
+	}
+	err = (*Varint)(&varint).Scan("1.5")
+	expectedError := "invalid varint: 1.5"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = (*Varint)(&varint).Scan(1.5)
+	expectedError = "varint source is not a string: float64"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	var decimal inf.Dec
+	err = (*Decimal)(&decimal).Scan("12345678901234567890.123456789")
+	if err != nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+	}
+	if decimal.String() != "12345678901234567890.123456789" {
+		t.Fatalf("Scan - received: %v - expected: %v", decimal.String(), "12345678901234567890.123456789")
+	}
+	err = (*Decimal)(&decimal).Scan("a")
+	expectedError = "invalid decimal: a"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = (*Decimal)(&decimal).Scan(1)
+	expectedError = "decimal source is not a string: int"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	var bigFloat big.Float
+	err = (*BigFloat)(&bigFloat).Scan("1.5")
+	if err != nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+	}
+	if bigFloat.String() != "1.5" {
+		t.Fatalf("Scan - received: %v - expected: %v", bigFloat.String(), "1.5")
+	}
+	err = (*BigFloat)(&bigFloat).Scan(true)
+	expectedError = "big float source is not a string: bool"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	varint.SetString(large, 10)
+	value := columnValue(gocql.NewNativeType(4, gocql.TypeVarint, ""), &varint)
+	if value != large {
+		t.Fatalf("columnValue - received: %v - expected: %v", value, large)
+	}
+	value = columnValue(gocql.NewNativeType(4, gocql.TypeDecimal, ""), inf.NewDec(12345, 2))
+	if value != "123.45" {
+		t.Fatalf("columnValue - received: %v - expected: %v", value, "123.45")
+	}
+	value = columnValue(gocql.NewNativeType(4, gocql.TypeDecimal, ""), (*inf.Dec)(nil))
+	if value != nil {
+		t.Fatalf("columnValue - received: %v - expected: %v", value, nil)
+	}
+}
+
+func TestBigNumberMigration(t *testing.T) {
+	// decimal and varint columns were the gocql *inf.Dec and *big.Int, they scan the same through cql.Decimal and cql.Varint
+	large, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	varintTests := []*big.Int{big.NewInt(0), big.NewInt(-1), big.NewInt(1 << 62), large}
+	for _, test := range varintTests {
+		var varint big.Int
+		err := (*Varint)(&varint).Scan(columnValue(gocql.NewNativeType(4, gocql.TypeVarint, ""), test))
+		if err != nil {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test, err, nil)
+		}
+		if varint.Cmp(test) != 0 {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test, &varint, test)
+		}
+	}
+
+	decimalTests := []*inf.Dec{inf.NewDec(0, 0), inf.NewDec(-12345, 2), inf.NewDec(12345, -3), inf.NewDecBig(large, 10)}
+	for _, test := range decimalTests {
+		var decimal inf.Dec
+		err := (*Decimal)(&decimal).Scan(columnValue(gocql.NewNativeType(4, gocql.TypeDecimal, ""), test))
+		if err != nil {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test, err, nil)
+		}
+		if decimal.Cmp(test) != 0 {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test, &decimal, test)
+		}
+	}
+
+	// null columns scan as zero
+	var varint big.Int
+	err := (*Varint)(&varint).Scan(columnValue(gocql.NewNativeType(4, gocql.TypeVarint, ""), (*big.Int)(nil)))
+	if err != nil || varint.Sign() != 0 {
+		t.Fatalf("Scan - received: %v, %v - expected: %v, %v", &varint, err, 0, nil)
+	}
+	var decimal inf.Dec
+	err = (*Decimal)(&decimal).Scan(columnValue(gocql.NewNativeType(4, gocql.TypeDecimal, ""), (*inf.Dec)(nil)))
+	if err != nil || decimal.Sign() != 0 {
+		t.Fatalf("Scan - received: %v, %v - expected: %v, %v", &decimal, err, 0, nil)
+	}
+}
+
+func TestBlobWriterScan(t *testing.T) {
+	large := bytes.Repeat([]byte{0, 1, 2, 3, 4, 5, 6, 7}, 1024*1024)
+
+	tests := []struct {
+		src      interface{}
+		expected []byte
+	}{
+		{src: nil, expected: []byte{}},
+		{src: []byte{}, expected: []byte{}},
+		{src: []byte("blob"), expected: []byte("blob")},
+		{src: "blob", expected: []byte("blob")},
+		{src: large, expected: large},
+	}
+
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		err := (&BlobWriter{Writer: &buffer}).Scan(test.src)
+		if err != nil {
+			t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+		}
+		if !bytes.Equal(buffer.Bytes(), test.expected) {
+			t.Fatalf("Scan - received: %v bytes - expected: %v bytes", buffer.Len(), len(test.expected))
+		}
+	}
+
+	var buffer bytes.Buffer
+	err := (&BlobWriter{Writer: &buffer}).Scan(1)
+	expectedError := "blob source is not a []byte: int"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = (&BlobWriter{}).Scan([]byte("blob"))
+	expectedError = "blob writer Writer is nil"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	// the blob is written as is, without a copy
+	var writer testBlobWriter
+	err = (&BlobWriter{Writer: &writer}).Scan(large)
+	if err != nil {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, nil)
+	}
+	if len(writer.writes) != 1 || &writer.writes[0][0] != &large[0] {
+		t.Fatalf("Scan writes - received: %v - expected: %v", len(writer.writes), 1)
+	}
+}
+
+type testBlobWriter struct {
+	writes [][]byte
+}
+
+func (writer *testBlobWriter) Write(data []byte) (int, error) {
+	writer.writes = append(writer.writes, data)
+	return len(data), nil
+}
+
+func TestIPScan(t *testing.T) {
+	tests := []struct {
+		src      interface{}
+		expected net.IP
+	}{
+		{src: nil, expected: nil},
+		{src: "", expected: nil},
+		{src: "127.0.0.1", expected: net.ParseIP("127.0.0.1")},
+		{src: "::1", expected: net.ParseIP("::1")},
+		{src: "2001:db8::68", expected: net.ParseIP("2001:db8::68")},
+		{src: net.ParseIP("10.0.0.1"), expected: net.ParseIP("10.0.0.1")},
+	}
+
+	for _, test := range tests {
+		ip := net.ParseIP("192.168.0.1")
+		err := (*IP)(&ip).Scan(test.src)
+		if err != nil {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test.src, err, nil)
+		}
+		if !ip.Equal(test.expected) || (test.expected == nil && ip != nil) {
+			t.Fatalf("Scan failed for: %v - received: %v - expected: %v", test.src, ip, test.expected)
+		}
+	}
+
+	var ip net.IP
+	err := (*IP)(&ip).Scan("a")
+	expectedError := "invalid ip: a"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+	err = (*IP)(&ip).Scan(1)
+	expectedError = "ip source is not a string: int"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Scan error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	namedValue := driver.NamedValue{Ordinal: 1, Value: net.ParseIP("127.0.0.1")}
+	err = checkNamedValue(&namedValue)
+	if err != nil {
+		t.Fatalf("checkNamedValue error - received: %v - expected: %v ", err, nil)
+	}
+	if _, ok := namedValue.Value.(net.IP); !ok {
+		t.Fatalf("checkNamedValue - received: %T - expected: %T", namedValue.Value, net.IP{})
+	}
+
+	// inet columns are net.IP, IPv4 addresses are 4 bytes
+	valueTests := []struct {
+		src      driver.Value
+		expected driver.Value
+	}{
+		{src: "192.168.1.10", expected: net.IP{192, 168, 1, 10}},
+		{src: "2001:db8::68", expected: net.ParseIP("2001:db8::68")},
+		{src: "a", expected: "a"},
+		{src: nil, expected: nil},
+	}
+	for _, test := range valueTests {
+		value := columnValue(gocql.NewNativeType(4, gocql.TypeInet, ""), test.src)
+		if !reflect.DeepEqual(value, test.expected) {
+			t.Fatalf("columnValue failed for: %v - received: %#v - expected: %#v", test.src, value, test.expected)
+		}
+	}
+}
+
+func TestNullableToValue(t *testing.T) {
+	text := "a"
+	textPointer := &text
+	number := 1
+	numberPointer := &number
+	boolean := true
+	booleanPointer := &boolean
+	var nilText *string
+	var nilNumber *int
+	var nilBoolean *bool
+
+	tests := []struct {
+		src      interface{}
+		dest     sql.Scanner
+		expected interface{}
+	}{
+		{src: &nilText, dest: &sql.NullString{}, expected: sql.NullString{}},
+		{src: &textPointer, dest: &sql.NullString{}, expected: sql.NullString{String: "a", Valid: true}},
+		{src: &nilNumber, dest: &sql.NullInt64{}, expected: sql.NullInt64{}},
+		{src: &numberPointer, dest: &sql.NullInt64{}, expected: sql.NullInt64{Int64: 1, Valid: true}},
+		{src: &nilNumber, dest: &sql.NullFloat64{}, expected: sql.NullFloat64{}},
+		{src: &numberPointer, dest: &sql.NullFloat64{}, expected: sql.NullFloat64{Float64: 1, Valid: true}},
+		{src: &nilBoolean, dest: &sql.NullBool{}, expected: sql.NullBool{}},
+		{src: &booleanPointer, dest: &sql.NullBool{}, expected: sql.NullBool{Bool: true, Valid: true}},
+	}
+
+	for _, test := range tests {
+		value, err := nullableToValue(test.src)
+		if err != nil {
+			t.Fatalf("nullableToValue failed for: %T - received: %v - expected: %v", test.src, err, nil)
+		}
+		err = test.dest.Scan(value)
+		if err != nil {
+			t.Fatalf("Scan failed for: %T - received: %v - expected: %v", test.src, err, nil)
+		}
+		received := reflect.ValueOf(test.dest).Elem().Interface()
+		if received != test.expected {
+			t.Fatalf("Scan failed for: %T - received: %v - expected: %v", test.src, received, test.expected)
+		}
+	}
+
+	_, err := nullableToValue(&text)
+	expectedError := "source is not a pointer to a pointer"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("nullableToValue error - received: %v - expected: %v ", err, expectedError)
+	}
+
+	nullableTests := []struct {
+		typeInfo gocql.TypeInfo
+		expected bool
+	}{
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeVarchar, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeInt, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeBoolean, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeUUID, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeInet, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeTimestamp, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeDate, ""), expected: true},
+		{typeInfo: gocql.NewNativeType(4, gocql.TypeDecimal, ""), expected: false},
+		{typeInfo: gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeList, ""), Elem: gocql.NewNativeType(4, gocql.TypeInt, "")}, expected: false},
+		{typeInfo: nil, expected: false},
+	}
+
+	for _, test := range nullableTests {
+		nullable := isNullableColumn(test.typeInfo)
+		if nullable != test.expected {
+			t.Fatalf("isNullableColumn failed for: %v - received: %v - expected: %v", test.typeInfo, nullable, test.expected)
+		}
+	}
+}
+
+func TestEmptyCollection(t *testing.T) {
+	tests := []struct {
+		typeInfo gocql.TypeInfo
+		expected interface{}
+	}{
+		{typeInfo: gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeList, ""), Elem: gocql.NewNativeType(4, gocql.TypeInt, "")}, expected: []int32{}},
+		{typeInfo: gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeSet, ""), Elem: gocql.NewNativeType(4, gocql.TypeVarchar, "")}, expected: []string{}},
+		{typeInfo: gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: gocql.NewNativeType(4, gocql.TypeVarchar, ""), Elem: gocql.NewNativeType(4, gocql.TypeInt, "")}, expected: map[string]int32(nil)},
+		{typeInfo: gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: gocql.NewNativeType(4, gocql.TypeVarchar, ""), Elem: gocql.NewNativeType(4, gocql.TypeVarchar, "")}, expected: map[string]string(nil)},
+	}
+
+	for _, test := range tests {
+		// null column
+		value := columnScanValue(test.typeInfo)
+		err := gocql.Unmarshal(test.typeInfo, nil, value)
+		if err != nil {
+			t.Fatalf("Unmarshal failed for: %v - received: %v - expected: %v", test.typeInfo, err, nil)
+		}
+		emptyCollection(value)
+		received := reflect.ValueOf(value).Elem().Interface()
+		if !reflect.DeepEqual(received, test.expected) {
+			t.Fatalf("emptyCollection failed for: %v - received: %#v - expected: %#v", test.typeInfo, received, test.expected)
+		}
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	type embedded struct {
+		Text  string `cql:"text_data"`
+		Int   int    `cql:"embedded_data"`
+		Other string
+	}
+	type row struct {
+		embedded
+		Text    string   `cql:"text_data"`
+		List    []string `cql:"list_text_data"`
+		Ignored int
+		Rest    map[string]interface{} `cql:"*"`
+	}
+
+	fields, catchAll, err := structFields(reflect.TypeOf(row{}))
+	if err != nil {
+		t.Fatalf("structFields error - received: %v - expected: %v ", err, nil)
+	}
+	expectedFields := map[string][]int{"text_data": {1}, "list_text_data": {2}, "embedded_data": {0, 1}}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Fatalf("structFields fields - received: %v - expected: %v ", fields, expectedFields)
+	}
+	if !reflect.DeepEqual(catchAll, []int{4}) {
+		t.Fatalf("structFields catchAll - received: %v - expected: %v ", catchAll, []int{4})
+	}
+
+	errorTests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: struct {
+			A string `cql:"a"`
+			B string `cql:"a"`
+		}{}, expected: "duplicate cql tag: a"},
+		{value: struct {
+			Rest map[string]string `cql:"*"`
+		}{}, expected: "cql tag * field is not map[string]interface{}: Rest"},
+		{value: struct {
+			a string `cql:"a"`
+		}{}, expected: "cql tag on unexported field: a"},
+	}
+
+	for _, test := range errorTests {
+		_, _, err = structFields(reflect.TypeOf(test.value))
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("structFields error - received: %v - expected: %v ", err, test.expected)
+		}
+	}
+}
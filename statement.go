@@ -3,7 +3,10 @@ package cql
 import (
 	"context"
 	"database/sql/driver"
+	"net"
 	"reflect"
+
+	"github.com/gocql/gocql"
 )
 
 // Close a statement
@@ -27,29 +30,56 @@ func (cqlStmt *CqlStmt) Exec(args []driver.Value) (driver.Result, error) {
 
 // ExecContext executes a statement with context
 func (cqlStmt *CqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	values, err := namedValuesToInterface(args)
+	values, err := cqlStmt.bindValues(args)
 	if err != nil {
 		return nil, err
 	}
 	return cqlStmt.execContext(ctx, values)
 }
 
-// execContext executes a statement with context
+// execContext executes a statement with context.
+// During a transaction the statement is added to the transaction batch instead.
 func (cqlStmt *CqlStmt) execContext(ctx context.Context, values []interface{}) (driver.Result, error) {
 	query := cqlStmt.CqlQuery
 	if query == nil {
 		return nil, ErrQueryIsNil
 	}
+	if cqlStmt.conn != nil && cqlStmt.conn.tx != nil {
+		err := cqlStmt.conn.tx.add(query.Statement(), values)
+		if err != nil {
+			return nil, err
+		}
+		return cqlResultStruct{}, nil
+	}
+	err := checkContext(ctx, cqlStmt.protoVersion())
+	if err != nil {
+		return nil, err
+	}
 
-	query = query.WithContext(ctx)
+	query = applyContext(ctx, cqlStmt.session, query.WithContext(ctx))
 	if len(values) > 0 {
 		query = query.Bind(values...)
 	}
-	err := query.Exec()
+	iter := query.Iter()
+	setWarnings(ctx, iter)
+	setResponseCustomPayload(ctx, iter)
+	setQueryStats(ctx, query, iter)
+	err = iter.Close()
 	if err != nil {
+		cqlStmt.conn.checkFatalError(err)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, err
 	}
 
+	if schemaVersion, ok := schemaVersionFromContext(ctx, query.Statement()); ok && cqlStmt.session != nil {
+		err = setSchemaVersion(schemaVersion, cqlStmt.session.Query(schemaVersionStatement).Consistency(gocql.One).WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return cqlResultStruct{}, nil
 }
 
@@ -60,32 +90,94 @@ func (cqlStmt *CqlStmt) Query(args []driver.Value) (driver.Rows, error) {
 
 // QueryContext queries a statement with context
 func (cqlStmt *CqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	values, err := namedValuesToInterface(args)
+	values, err := cqlStmt.bindValues(args)
 	if err != nil {
 		return nil, err
 	}
 	return cqlStmt.queryContext(ctx, values)
 }
 
-// queryContext queries a statement with context
+// queryContext queries a statement with context.
+// Returns ErrTxQueryNotSupported during a transaction, a batch can not return rows.
 func (cqlStmt *CqlStmt) queryContext(ctx context.Context, values []interface{}) (driver.Rows, error) {
 	query := cqlStmt.CqlQuery
 	if query == nil {
 		return nil, ErrQueryIsNil
 	}
+	if cqlStmt.conn != nil && cqlStmt.conn.tx != nil {
+		return nil, ErrTxQueryNotSupported
+	}
+	err := checkContext(ctx, cqlStmt.protoVersion())
+	if err != nil {
+		return nil, err
+	}
 
-	query = query.WithContext(ctx)
+	query = applyContext(ctx, cqlStmt.session, query.WithContext(ctx))
 	if len(values) > 0 {
 		query = query.Bind(values...)
 	}
 
 	iter := query.Iter()
+	if ctx.Err() != nil {
+		iter.Close()
+		return nil, ctx.Err()
+	}
+	setNextPageState(ctx, iter)
+	setWarnings(ctx, iter)
+	setResponseCustomPayload(ctx, iter)
+	setQueryStats(ctx, query, iter)
 	return &cqlRowsStruct{
-		iter:    iter,
-		columns: columnInfoToString(iter.Columns()),
+		iter:       iter,
+		columns:    columnInfoToString(iter.Columns()),
+		columnInfo: iter.Columns(),
+		session:    cqlStmt.session,
+		conn:       cqlStmt.conn,
 	}, nil
 }
 
+// protoVersion returns the protocol version of the connection cluster config, 0 when unknown
+func (cqlStmt *CqlStmt) protoVersion() int {
+	if cqlStmt.conn == nil {
+		return 0
+	}
+	return cqlStmt.conn.protoVersion()
+}
+
+// bindValues converts named values to bind values and checks named values against the statement bind markers
+func (cqlStmt *CqlStmt) bindValues(args []driver.NamedValue) ([]interface{}, error) {
+	values, err := namedValuesToInterface(args)
+	if err != nil {
+		return nil, err
+	}
+	if cqlStmt.CqlQuery != nil {
+		err = checkBindMarkers(cqlStmt.CqlQuery.Statement(), args)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// CheckNamedValue converts a named value for the statement.
+// Values the driver can not convert, like maps and slices, are passed to gocql as is.
+func (cqlStmt *CqlStmt) CheckNamedValue(namedValue *driver.NamedValue) error {
+	return checkNamedValue(namedValue)
+}
+
+// checkNamedValue converts a named value using converter, leaving values gocql can marshal as is
+func checkNamedValue(namedValue *driver.NamedValue) error {
+	switch namedValue.Value.(type) {
+	case net.IP, gocql.Marshaler:
+		// converter would change net.IP to []byte which gocql can not marshal into inet
+		return nil
+	}
+	value, err := converter{}.ConvertValue(namedValue.Value)
+	if err == nil {
+		namedValue.Value = value
+	}
+	return nil
+}
+
 // ColumnConverter provides driver ValueConverter for statment
 func (cqlStmt *CqlStmt) ColumnConverter(index int) driver.ValueConverter {
 	return converter{}
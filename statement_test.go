@@ -3,7 +3,11 @@ package cql
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
+	"reflect"
 	"testing"
+
+	"github.com/gocql/gocql"
 )
 
 func TestStatementNumInput(t *testing.T) {
@@ -101,9 +105,18 @@ func TestStatementExecContext(t *testing.T) {
 		t.Fatal("result is not nil")
 	}
 
-	result, err = cqlStmt.ExecContext(context.Background(), []driver.NamedValue{{Name: "a"}})
-	if err == nil || err != ErrNamedValuesNotSupported {
-		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, ErrNamedValuesNotSupported)
+	result, err = cqlStmt.ExecContext(context.Background(), []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}})
+	expectedError = "no bind marker for named value: a"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, expectedError)
+	}
+	if result != nil {
+		t.Fatal("result is not nil")
+	}
+
+	result, err = cqlStmt.ExecContext(context.Background(), []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}, {Ordinal: 2, Value: 2}})
+	if err == nil || err != ErrNamedValuesMixed {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, ErrNamedValuesMixed)
 	}
 	if result != nil {
 		t.Fatal("result is not nil")
@@ -219,9 +232,18 @@ func TestStatementQueryContext(t *testing.T) {
 		t.Fatalf("Close error expected")
 	}
 
-	rows, err = cqlStmt.QueryContext(context.Background(), []driver.NamedValue{{Name: "a"}})
-	if err == nil || err != ErrNamedValuesNotSupported {
-		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, ErrNamedValuesNotSupported)
+	rows, err = cqlStmt.QueryContext(context.Background(), []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}})
+	expectedError := "no bind marker for named value: a"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, expectedError)
+	}
+	if rows != nil {
+		t.Fatal("rows is not nil")
+	}
+
+	rows, err = cqlStmt.QueryContext(context.Background(), []driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}, {Ordinal: 2, Value: 2}})
+	if err == nil || err != ErrNamedValuesMixed {
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, ErrNamedValuesMixed)
 	}
 	if rows != nil {
 		t.Fatal("rows is not nil")
@@ -266,6 +288,188 @@ func TestStatementQueryContext(t *testing.T) {
 	}
 }
 
+func TestBindMarkerNames(t *testing.T) {
+	tests := []struct {
+		statement string
+		markers   []string
+	}{
+		{statement: "", markers: []string{}},
+		{statement: "select * from a where b = ?", markers: []string{}},
+		{statement: "select * from a where b = :b", markers: []string{"b"}},
+		{statement: "select * from a where b = :b and c = :c_2", markers: []string{"b", "c_2"}},
+		{statement: "insert into a (b, c) values (:b,:c)", markers: []string{"b", "c"}},
+		{statement: "select * from a where b = ':b' and c = :c", markers: []string{"c"}},
+		{statement: "select * from a where b = 'it''s :b' and c = :c", markers: []string{"c"}},
+		{statement: "select \":b\" from a where c = :c", markers: []string{"c"}},
+		{statement: "insert into a (b) values ({'x':1, 'y': :y})", markers: []string{"y"}},
+		{statement: "select * from a where b = :", markers: []string{}},
+		{statement: "select * from a where b = :1", markers: []string{}},
+	}
+
+	for _, test := range tests {
+		markers := bindMarkerNames(test.statement)
+		if len(markers) != len(test.markers) {
+			t.Fatalf("bindMarkerNames failed for: %v - received: %v - expected: %v", test.statement, markers, test.markers)
+		}
+		for _, marker := range test.markers {
+			if _, ok := markers[marker]; !ok {
+				t.Fatalf("bindMarkerNames failed for: %v - received: %v - expected: %v", test.statement, markers, test.markers)
+			}
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		statement  string
+		statements []string
+	}{
+		{statement: "", statements: nil},
+		{statement: " ; ;", statements: nil},
+		{statement: "select * from a", statements: []string{"select * from a"}},
+		{statement: "select * from a;", statements: []string{"select * from a"}},
+		{statement: "insert into a (b) values (1); select * from a", statements: []string{"insert into a (b) values (1)", "select * from a"}},
+		{statement: "insert into a (b) values ('x;y');\nselect * from a;\n", statements: []string{"insert into a (b) values ('x;y')", "select * from a"}},
+		{statement: "insert into a (b) values ('it''s;');select \"c;d\" from a", statements: []string{"insert into a (b) values ('it''s;')", "select \"c;d\" from a"}},
+		{statement: "insert into a (b) values ($$x;y$$); select * from a", statements: []string{"insert into a (b) values ($$x;y$$)", "select * from a"}},
+		{statement: "select * from a; -- done; really", statements: []string{"select * from a"}},
+		{statement: "select * from a; // done;\nselect * from b", statements: []string{"select * from a", "// done;\nselect * from b"}},
+		{statement: "select * from a /* ; */ where b = 1; /* ; */", statements: []string{"select * from a /* ; */ where b = 1"}},
+		{statement: "BEGIN BATCH INSERT INTO a (b) VALUES (1); INSERT INTO a (b) VALUES (2); APPLY BATCH;",
+			statements: []string{"BEGIN BATCH INSERT INTO a (b) VALUES (1); INSERT INTO a (b) VALUES (2); APPLY BATCH"}},
+		{statement: "begin unlogged batch insert into a (b) values (?); update a set c = ? where b = ?;\napply batch",
+			statements: []string{"begin unlogged batch insert into a (b) values (?); update a set c = ? where b = ?;\napply batch"}},
+		{statement: "begin counter batch update a set c = c + 1 where b = 1; apply  batch ; select * from a;",
+			statements: []string{"begin counter batch update a set c = c + 1 where b = 1; apply  batch", "select * from a"}},
+		{statement: "/* batch */ begin batch insert into a (b) values ('apply batch'); apply batch; select * from a",
+			statements: []string{"/* batch */ begin batch insert into a (b) values ('apply batch'); apply batch", "select * from a"}},
+		{statement: "begin batch insert into a (b) values (1);", statements: []string{"begin batch insert into a (b) values (1);"}},
+	}
+
+	for _, test := range tests {
+		statements := splitStatements(test.statement)
+		if !reflect.DeepEqual(statements, test.statements) {
+			t.Fatalf("splitStatements failed for: %v - received: %#v - expected: %#v", test.statement, statements, test.statements)
+		}
+	}
+}
+
+func TestUnpreparedStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  string
+	}{
+		{statement: "select * from a", expected: "/* unprepared */ select * from a"},
+		{statement: "insert into a (b) values ('?:b')", expected: "/* unprepared */ insert into a (b) values ('?:b')"},
+		{statement: "select \"a?\" from a", expected: "/* unprepared */ select \"a?\" from a"},
+		{statement: "select * from a where b = ?", expected: "select * from a where b = ?"},
+		{statement: "select * from a where b = :b", expected: "select * from a where b = :b"},
+		{statement: "insert into a (b) values ({'x': :y})", expected: "insert into a (b) values ({'x': :y})"},
+	}
+
+	for _, test := range tests {
+		statement := unpreparedStatement(test.statement)
+		if statement != test.expected {
+			t.Fatalf("unpreparedStatement failed for: %v - received: %v - expected: %v", test.statement, statement, test.expected)
+		}
+	}
+}
+
+func TestStatementVerb(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  string
+	}{
+		{statement: "select * from a", expected: "select"},
+		{statement: "  SELECT * from a", expected: "select"},
+		{statement: "/* unprepared */ insert into a (b) values (1)", expected: "insert"},
+		{statement: "-- comment\nUpdate a set b = 1", expected: "update"},
+		{statement: "// comment\n\tdelete from a", expected: "delete"},
+		{statement: "begin batch insert into a (b) values (1) apply batch", expected: "begin"},
+		{statement: "/* comment", expected: ""},
+		{statement: "", expected: ""},
+	}
+
+	for _, test := range tests {
+		verb := statementVerb(test.statement)
+		if verb != test.expected {
+			t.Fatalf("statementVerb failed for: %v - received: %v - expected: %v", test.statement, verb, test.expected)
+		}
+	}
+}
+
+func TestCreateKeyspaceStatement(t *testing.T) {
+	tests := []struct {
+		name        string
+		replication map[string]interface{}
+		statement   string
+		err         error
+	}{
+		{name: "test", replication: map[string]interface{}{"class": "SimpleStrategy", "replication_factor": 1},
+			statement: "create keyspace if not exists test with replication = {'class': 'SimpleStrategy', 'replication_factor': 1}"},
+		{name: "test_2", replication: map[string]interface{}{"class": "NetworkTopologyStrategy", "dc2": "2", "dc1": 3},
+			statement: "create keyspace if not exists test_2 with replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': '2'}"},
+		{name: "test", replication: map[string]interface{}{"class": "it's"},
+			statement: "create keyspace if not exists test with replication = {'class': 'it''s'}"},
+		{name: "", replication: map[string]interface{}{"class": "SimpleStrategy"}, err: fmt.Errorf("invalid keyspace name: ")},
+		{name: "1test", replication: map[string]interface{}{"class": "SimpleStrategy"}, err: fmt.Errorf("invalid keyspace name: 1test")},
+		{name: "test; drop keyspace test", replication: map[string]interface{}{"class": "SimpleStrategy"}, err: fmt.Errorf("invalid keyspace name: test; drop keyspace test")},
+		{name: "test", replication: map[string]interface{}{"replication_factor": 1}, err: fmt.Errorf("replication is missing class")},
+		{name: "test", replication: nil, err: fmt.Errorf("replication is missing class")},
+		{name: "test", replication: map[string]interface{}{"class": "SimpleStrategy", "replication_factor": true}, err: fmt.Errorf("failed for: replication replication_factor = true")},
+	}
+
+	for _, test := range tests {
+		statement, err := createKeyspaceStatement(test.name, test.replication)
+		if test.err != nil {
+			if err == nil || err.Error() != test.err.Error() {
+				t.Fatalf("createKeyspaceStatement error - received: %v - expected: %v - name: %v", err, test.err, test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("createKeyspaceStatement error - received: %v - expected: %v - name: %v", err, nil, test.name)
+		}
+		if statement != test.statement {
+			t.Fatalf("createKeyspaceStatement failed for: %v - received: %v - expected: %v", test.name, statement, test.statement)
+		}
+	}
+}
+
+func TestNamedValuesToInterface(t *testing.T) {
+	values, err := namedValuesToInterface([]driver.NamedValue{{Ordinal: 2, Value: 2}, {Ordinal: 1, Value: 1}})
+	if err != nil {
+		t.Fatalf("namedValuesToInterface error - received: %v - expected: %v ", err, nil)
+	}
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("namedValuesToInterface - received: %v - expected: %v ", values, []interface{}{1, 2})
+	}
+
+	values, err = namedValuesToInterface([]driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}, {Name: "b", Ordinal: 2, Value: 2}})
+	if err != nil {
+		t.Fatalf("namedValuesToInterface error - received: %v - expected: %v ", err, nil)
+	}
+	expected := []interface{}{gocql.NamedValue("a", 1), gocql.NamedValue("b", 2)}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("namedValuesToInterface - received: %v - expected: %v ", values, expected)
+	}
+
+	_, err = namedValuesToInterface([]driver.NamedValue{{Name: "a", Ordinal: 1, Value: 1}, {Ordinal: 2, Value: 2}})
+	if err == nil || err != ErrNamedValuesMixed {
+		t.Fatalf("namedValuesToInterface error - received: %v - expected: %v ", err, ErrNamedValuesMixed)
+	}
+
+	err = checkBindMarkers("select * from a where b = :b", []driver.NamedValue{{Name: "b", Ordinal: 1, Value: 1}})
+	if err != nil {
+		t.Fatalf("checkBindMarkers error - received: %v - expected: %v ", err, nil)
+	}
+	err = checkBindMarkers("select * from a where b = :b", []driver.NamedValue{{Name: "c", Ordinal: 1, Value: 1}})
+	expectedError := "no bind marker for named value: c"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("checkBindMarkers error - received: %v - expected: %v ", err, expectedError)
+	}
+}
+
 func testGetRowsHostValid(t *testing.T, query string) (driver.Conn, driver.Stmt, driver.Rows) {
 	conn, stmt := testGetStatementHostValid(t, query)
 	if stmt == nil {
@@ -0,0 +1,36 @@
+package cql
+
+// Commit executes the statements of the transaction in a batch with the context of BeginTx.
+// A transaction without statements does nothing.
+func (cqlTx *cqlTxStruct) Commit() error {
+	if cqlTx.conn.tx == cqlTx {
+		cqlTx.conn.tx = nil
+	}
+	statements := cqlTx.statements
+	cqlTx.statements = nil
+	if len(statements) < 1 {
+		return nil
+	}
+	return cqlTx.conn.BatchExec(cqlTx.context, cqlTx.batchType, statements)
+}
+
+// Rollback discards the statements of the transaction, nothing has been sent to the server
+func (cqlTx *cqlTxStruct) Rollback() error {
+	if cqlTx.conn.tx == cqlTx {
+		cqlTx.conn.tx = nil
+	}
+	cqlTx.statements = nil
+	return nil
+}
+
+// add adds a statement to the batch of the transaction.
+// Returns ErrTxQueryNotSupported when the statement is not an insert, update, or delete statement.
+func (cqlTx *cqlTxStruct) add(statement string, values []interface{}) error {
+	switch statementVerb(statement) {
+	case "insert", "update", "delete":
+	default:
+		return ErrTxQueryNotSupported
+	}
+	cqlTx.statements = append(cqlTx.statements, BatchStatement{Statement: statement, Values: values})
+	return nil
+}
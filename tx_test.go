@@ -0,0 +1,113 @@
+package cql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestTx(t *testing.T) {
+	cqlConn := &cqlConnStruct{session: &gocql.Session{}}
+
+	tests := []struct {
+		ctx       context.Context
+		opts      driver.TxOptions
+		batchType gocql.BatchType
+		err       error
+	}{
+		{ctx: context.Background(), batchType: gocql.LoggedBatch},
+		{ctx: WithBatchType(context.Background(), gocql.UnloggedBatch), batchType: gocql.UnloggedBatch},
+		{ctx: WithBatchType(context.Background(), gocql.CounterBatch), batchType: gocql.CounterBatch},
+		{ctx: WithBatchType(context.Background(), gocql.BatchType(9)), err: fmt.Errorf("invalid batch type: 9")},
+		{ctx: context.Background(), opts: driver.TxOptions{ReadOnly: true}, err: ErrNotSupported},
+		{ctx: context.Background(), opts: driver.TxOptions{Isolation: driver.IsolationLevel(6)}, err: ErrNotSupported},
+	}
+
+	for i, test := range tests {
+		tx, err := cqlConn.BeginTx(test.ctx, test.opts)
+		if err != nil {
+			if test.err == nil || err.Error() != test.err.Error() {
+				t.Fatalf("BeginTx %v error - received: %v - expected: %v ", i, err, test.err)
+			}
+			continue
+		}
+		if test.err != nil {
+			t.Fatalf("BeginTx %v error - received: %v - expected: %v ", i, err, test.err)
+		}
+		batchType := tx.(*cqlTxStruct).batchType
+		if batchType != test.batchType {
+			t.Fatalf("BeginTx %v batchType - received: %v - expected: %v ", i, batchType, test.batchType)
+		}
+		err = tx.Rollback()
+		if err != nil {
+			t.Fatalf("Rollback %v error - received: %v - expected: %v ", i, err, nil)
+		}
+	}
+}
+
+func TestTxRollback(t *testing.T) {
+	cqlConn := &cqlConnStruct{session: &gocql.Session{}}
+
+	tx, err := cqlConn.BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatalf("BeginTx error - received: %v - expected: %v ", err, nil)
+	}
+
+	_, err = cqlConn.ExecContext(context.Background(), "insert into t (a, b) values (?, ?)", []driver.NamedValue{{Ordinal: 1, Value: "one"}, {Ordinal: 2, Value: int64(1)}})
+	if err != nil {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, nil)
+	}
+	_, err = cqlConn.ExecContext(context.Background(), "update t set b = 2 where a = 'two'; delete from t where a = 'three'", nil)
+	if err != nil {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, nil)
+	}
+
+	// queries and statements that can not be in a batch
+	_, err = cqlConn.ExecContext(context.Background(), "select a from t", nil)
+	if err == nil || err != ErrTxQueryNotSupported {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, ErrTxQueryNotSupported)
+	}
+	_, err = cqlConn.ExecContext(context.Background(), "truncate table t", nil)
+	if err == nil || err != ErrTxQueryNotSupported {
+		t.Fatalf("ExecContext error - received: %v - expected: %v ", err, ErrTxQueryNotSupported)
+	}
+	_, err = cqlConn.QueryContext(context.Background(), "select a from t", nil)
+	if err == nil || err != ErrTxQueryNotSupported {
+		t.Fatalf("QueryContext error - received: %v - expected: %v ", err, ErrTxQueryNotSupported)
+	}
+	_, _, err = cqlConn.ExecCAS(context.Background(), "insert into t (a) values ('four') if not exists")
+	if err == nil || err != ErrTxQueryNotSupported {
+		t.Fatalf("ExecCAS error - received: %v - expected: %v ", err, ErrTxQueryNotSupported)
+	}
+
+	expected := []BatchStatement{
+		{Statement: "insert into t (a, b) values (?, ?)", Values: []interface{}{"one", int64(1)}},
+		{Statement: "update t set b = 2 where a = 'two'"},
+		{Statement: "delete from t where a = 'three'"},
+	}
+	statements := tx.(*cqlTxStruct).statements
+	if !reflect.DeepEqual(statements, expected) {
+		t.Fatalf("statements - received: %v - expected: %v ", statements, expected)
+	}
+
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatalf("Rollback error - received: %v - expected: %v ", err, nil)
+	}
+	if cqlConn.tx != nil {
+		t.Fatal("tx is not nil")
+	}
+	if len(tx.(*cqlTxStruct).statements) > 0 {
+		t.Fatalf("statements - received: %v - expected: %v ", tx.(*cqlTxStruct).statements, nil)
+	}
+
+	// commit after rollback has no statements to execute
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("Commit error - received: %v - expected: %v ", err, nil)
+	}
+}
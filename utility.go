@@ -1,12 +1,20 @@
 package cql
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"io"
+	"math/big"
+	"net"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
 )
 
 // valuesToInterface coverts driver.Value to interface
@@ -18,21 +26,222 @@ func valuesToInterface(args []driver.Value) []interface{} {
 	return values
 }
 
-// namedValuesToInterface coverts driver.NamedValue to interface
+// namedValuesToInterface coverts driver.NamedValue to interface.
+// Named values are wrapped with gocql.NamedValue so they bind to named bind markers.
 func namedValuesToInterface(namedValues []driver.NamedValue) ([]interface{}, error) {
 	values := make([]interface{}, len(namedValues))
+	named := 0
 	for i := 0; i < len(namedValues); i++ {
-		if len(namedValues[i].Name) > 0 {
-			return []interface{}{}, ErrNamedValuesNotSupported
-		}
 		if namedValues[i].Ordinal < 1 || namedValues[i].Ordinal > len(namedValues) {
 			return []interface{}{}, ErrOrdinalOutOfRange
 		}
-		values[namedValues[i].Ordinal-1] = namedValues[i].Value
+		if len(namedValues[i].Name) > 0 {
+			named++
+			values[namedValues[i].Ordinal-1] = gocql.NamedValue(namedValues[i].Name, namedValues[i].Value)
+		} else {
+			values[namedValues[i].Ordinal-1] = namedValues[i].Value
+		}
+	}
+	if named > 0 && named != len(namedValues) {
+		return []interface{}{}, ErrNamedValuesMixed
 	}
 	return values, nil
 }
 
+// checkBindMarkers checks every named value has a matching named bind marker in the statement
+func checkBindMarkers(statement string, namedValues []driver.NamedValue) error {
+	var markers map[string]struct{}
+	for i := 0; i < len(namedValues); i++ {
+		if len(namedValues[i].Name) < 1 {
+			continue
+		}
+		if markers == nil {
+			markers = bindMarkerNames(statement)
+		}
+		if _, ok := markers[namedValues[i].Name]; !ok {
+			return fmt.Errorf("no bind marker for named value: %v", namedValues[i].Name)
+		}
+	}
+	return nil
+}
+
+// bindMarkerNames returns the names of the named bind markers in a statement, skipping quoted strings and identifiers
+func bindMarkerNames(statement string) map[string]struct{} {
+	markers := make(map[string]struct{})
+	for i := 0; i < len(statement); i++ {
+		switch statement[i] {
+		case '\'', '"':
+			quote := statement[i]
+			for i++; i < len(statement); i++ {
+				if statement[i] == quote {
+					if i+1 < len(statement) && statement[i+1] == quote {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case ':':
+			if i+1 >= len(statement) || !isIdentifierStart(statement[i+1]) {
+				continue
+			}
+			start := i + 1
+			for i = start; i+1 < len(statement) && isIdentifierPart(statement[i+1]); i++ {
+			}
+			markers[statement[start:i+1]] = struct{}{}
+		}
+	}
+	return markers
+}
+
+// splitStatements splits semicolon separated statements, skipping quoted strings, identifiers, and comments.
+// A begin batch statement is not split before its apply batch, the semicolons between its statements are kept.
+// Empty statements and statements with only comments are removed.
+func splitStatements(statement string) []string {
+	var statements []string
+	start := 0
+	// hasContent is set when the current statement has more than white space and comments
+	hasContent := false
+	for i := 0; i < len(statement); i++ {
+		switch {
+		case statement[i] == '\'' || statement[i] == '"':
+			hasContent = true
+			quote := statement[i]
+			for i++; i < len(statement); i++ {
+				if statement[i] == quote {
+					if i+1 < len(statement) && statement[i+1] == quote {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case strings.HasPrefix(statement[i:], "$$"):
+			hasContent = true
+			end := strings.Index(statement[i+2:], "$$")
+			if end < 0 {
+				i = len(statement)
+			} else {
+				i += end + 3
+			}
+		case strings.HasPrefix(statement[i:], "--") || strings.HasPrefix(statement[i:], "//"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				i = len(statement)
+			} else {
+				i += end
+			}
+		case strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				i = len(statement)
+			} else {
+				i += end + 3
+			}
+		case statement[i] == ';':
+			if isOpenBatch(statement[start:i]) {
+				continue
+			}
+			if hasContent {
+				statements = append(statements, strings.TrimSpace(statement[start:i]))
+			}
+			start = i + 1
+			hasContent = false
+		case statement[i] != ' ' && statement[i] != '\t' && statement[i] != '\n' && statement[i] != '\r':
+			hasContent = true
+		}
+	}
+	if hasContent {
+		statements = append(statements, strings.TrimSpace(statement[start:]))
+	}
+	return statements
+}
+
+// isOpenBatch returns true if the statement is a begin batch statement that does not end with apply batch yet
+func isOpenBatch(statement string) bool {
+	if statementVerb(statement) != "begin" {
+		return false
+	}
+	words := strings.Fields(strings.ToLower(statement))
+	return len(words) < 2 || words[len(words)-2] != "apply" || words[len(words)-1] != "batch"
+}
+
+// unpreparedStatement returns the statement with a leading comment so gocql does not prepare it,
+// gocql prepares select, insert, update, delete, and batch statements by their first word.
+// gocql only sends bind values with prepared statements, so statements with bind markers are returned as is.
+func unpreparedStatement(statement string) string {
+	if hasBindMarkers(statement) {
+		return statement
+	}
+	return "/* unprepared */ " + statement
+}
+
+// hasBindMarkers returns true if the statement has positional or named bind markers, skipping quoted strings and identifiers
+func hasBindMarkers(statement string) bool {
+	for i := 0; i < len(statement); i++ {
+		switch statement[i] {
+		case '\'', '"':
+			quote := statement[i]
+			for i++; i < len(statement); i++ {
+				if statement[i] == quote {
+					if i+1 < len(statement) && statement[i+1] == quote {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case '?':
+			return true
+		case ':':
+			if i+1 < len(statement) && isIdentifierStart(statement[i+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// statementVerb returns the lower case first word of the statement, skipping white space and comments
+func statementVerb(statement string) string {
+	i := 0
+	for i < len(statement) {
+		switch {
+		case statement[i] == ' ' || statement[i] == '\t' || statement[i] == '\n' || statement[i] == '\r':
+			i++
+		case strings.HasPrefix(statement[i:], "--") || strings.HasPrefix(statement[i:], "//"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				return ""
+			}
+			i += end + 1
+		case strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				return ""
+			}
+			i += end + 4
+		default:
+			start := i
+			for i < len(statement) && isIdentifierPart(statement[i]) {
+				i++
+			}
+			return strings.ToLower(statement[start:i])
+		}
+	}
+	return ""
+}
+
+// isIdentifierStart returns true if the byte can start an unquoted identifier
+func isIdentifierStart(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// isIdentifierPart returns true if the byte can be part of an unquoted identifier
+func isIdentifierPart(b byte) bool {
+	return isIdentifierStart(b) || (b >= '0' && b <= '9') || b == '_'
+}
+
 // columnInfoToString coverts gocql.ColumnInfo to string
 func columnInfoToString(columnInfo []gocql.ColumnInfo) []string {
 	names := make([]string, len(columnInfo))
@@ -42,6 +251,598 @@ func columnInfoToString(columnInfo []gocql.ColumnInfo) []string {
 	return names
 }
 
+// typeInfoToString coverts gocql.TypeInfo to CQL type name
+func typeInfoToString(typeInfo gocql.TypeInfo) string {
+	if typeInfo == nil {
+		return ""
+	}
+
+	switch info := typeInfo.(type) {
+	case gocql.CollectionType:
+		switch info.Type() {
+		case gocql.TypeList, gocql.TypeSet:
+			return info.Type().String() + "<" + typeInfoToString(info.Elem) + ">"
+		case gocql.TypeMap:
+			return "map<" + typeInfoToString(info.Key) + ", " + typeInfoToString(info.Elem) + ">"
+		}
+	case gocql.TupleTypeInfo:
+		names := make([]string, len(info.Elems))
+		for i := 0; i < len(info.Elems); i++ {
+			names[i] = typeInfoToString(info.Elems[i])
+		}
+		return "tuple<" + strings.Join(names, ", ") + ">"
+	case gocql.UDTTypeInfo:
+		return info.Name
+	}
+
+	switch typeInfo.Type() {
+	case gocql.TypeVarchar:
+		// varchar is an alias of text
+		return "text"
+	case gocql.TypeCustom:
+		return typeInfo.Custom()
+	}
+	return typeInfo.Type().String()
+}
+
+// typeInfoToScanType coverts gocql.TypeInfo to the Go type suitable for scanning into.
+// Decimal and varint columns are string, uuid and timeuuid columns are uuidScanType, inet columns are net.IP,
+// other types are their element scan type.
+func typeInfoToScanType(typeInfo gocql.TypeInfo) reflect.Type {
+	if typeInfo == nil {
+		return interfaceType
+	}
+	switch typeInfo.Type() {
+	case gocql.TypeDecimal, gocql.TypeVarint:
+		return reflect.TypeOf("")
+	case gocql.TypeUUID, gocql.TypeTimeUUID:
+		return uuidScanType
+	case gocql.TypeInet:
+		return reflect.TypeOf(net.IP{})
+	}
+	return elementScanType(typeInfo)
+}
+
+// elementScanType coverts gocql.TypeInfo to the Go type suitable for scanning into or for a collection element.
+// CQL int is 32 bits so it is int32, including as a list or set element or map key and value.
+// Frozen and nested collections are scanned recursively, like map<text, frozen<list<int>>> into map[string][]int32,
+// up to maxCollectionDepth. User defined types are the type registered by RegisterUDT, other types are what gocql creates for the type.
+// Sets are slices in the order returned by gocql, the order is not guaranteed.
+func elementScanType(typeInfo gocql.TypeInfo) reflect.Type {
+	return elementScanTypeDepth(typeInfo, 0)
+}
+
+// elementScanTypeDepth returns the elementScanType of typeInfo nested depth collections deep.
+// Collections nested deeper than maxCollectionDepth are what gocql creates, maps with keys that can not be Go map keys, like frozen lists, are interface{}.
+func elementScanTypeDepth(typeInfo gocql.TypeInfo, depth int) reflect.Type {
+	if typeInfo == nil {
+		return interfaceType
+	}
+	switch typeInfo.Type() {
+	case gocql.TypeInt:
+		return reflect.TypeOf(int32(0))
+	case gocql.TypeList, gocql.TypeSet:
+		if collectionType, ok := typeInfo.(gocql.CollectionType); ok && depth < maxCollectionDepth {
+			elemType := elementScanTypeDepth(collectionType.Elem, depth+1)
+			if elemType != interfaceType {
+				return reflect.SliceOf(elemType)
+			}
+		}
+	case gocql.TypeMap:
+		if collectionType, ok := typeInfo.(gocql.CollectionType); ok && depth < maxCollectionDepth {
+			keyType := elementScanTypeDepth(collectionType.Key, depth+1)
+			if !keyType.Comparable() {
+				// gocql panics creating a map with a key like a frozen list
+				return interfaceType
+			}
+			elemType := elementScanTypeDepth(collectionType.Elem, depth+1)
+			if keyType != interfaceType && elemType != interfaceType {
+				return reflect.MapOf(keyType, elemType)
+			}
+		}
+	case gocql.TypeUDT:
+		if udtTypeInfo, ok := typeInfo.(gocql.UDTTypeInfo); ok {
+			if valueType, ok := udtType(udtTypeInfo); ok {
+				return valueType
+			}
+		}
+	}
+	value, err := typeInfo.NewWithError()
+	if err != nil {
+		return interfaceType
+	}
+	return reflect.TypeOf(value).Elem()
+}
+
+// columnScanValue returns a pointer to scan a collection or registered user defined type column into
+// using the column scan type. Returns nil if gocql creates the value for the column.
+func columnScanValue(typeInfo gocql.TypeInfo) interface{} {
+	if typeInfo == nil {
+		return nil
+	}
+	switch typeInfo.Type() {
+	case gocql.TypeList, gocql.TypeSet, gocql.TypeMap, gocql.TypeUDT:
+		scanType := typeInfoToScanType(typeInfo)
+		if scanType == interfaceType {
+			return nil
+		}
+		return reflect.New(scanType).Interface()
+	}
+	return nil
+}
+
+// RegisterUDT registers the Go type of value for the user defined type keyspace.name.
+// Columns of the user defined type are scanned into the type by gocql,
+// so the type can implement gocql.UDTUnmarshaler or use cql struct tags.
+// Columns of user defined types not registered are scanned into map[string]interface{}.
+func RegisterUDT(keyspace string, name string, value interface{}) error {
+	valueType := reflect.TypeOf(value)
+	if valueType == nil || valueType.Kind() != reflect.Ptr {
+		return fmt.Errorf("value is not a pointer: %T", value)
+	}
+	udtTypesMutex.Lock()
+	udtTypes[keyspace+"."+name] = valueType.Elem()
+	udtTypesMutex.Unlock()
+	return nil
+}
+
+// udtType returns the Go type registered for the user defined type
+func udtType(udtTypeInfo gocql.UDTTypeInfo) (reflect.Type, bool) {
+	udtTypesMutex.RLock()
+	valueType, ok := udtTypes[udtTypeInfo.KeySpace+"."+udtTypeInfo.Name]
+	udtTypesMutex.RUnlock()
+	return valueType, ok
+}
+
+// EnsureKeyspace creates the keyspace name if it does not exist, with replication like
+// map[string]interface{}{"class": "SimpleStrategy", "replication_factor": 1}.
+// Replication needs a class, values are strings or numbers. An existing keyspace is not changed.
+func EnsureKeyspace(ctx context.Context, db *sql.DB, name string, replication map[string]interface{}) error {
+	statement, err := createKeyspaceStatement(name, replication)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, statement)
+	return err
+}
+
+// createKeyspaceStatement returns the create keyspace if not exists statement for EnsureKeyspace, replication keys are sorted
+func createKeyspaceStatement(name string, replication map[string]interface{}) (string, error) {
+	if name == "" || !isIdentifierStart(name[0]) {
+		return "", fmt.Errorf("invalid keyspace name: %v", name)
+	}
+	for i := 1; i < len(name); i++ {
+		if !isIdentifierPart(name[i]) {
+			return "", fmt.Errorf("invalid keyspace name: %v", name)
+		}
+	}
+	if _, ok := replication["class"]; !ok {
+		return "", fmt.Errorf("replication is missing class")
+	}
+
+	keys := make([]string, 0, len(replication))
+	for key := range replication {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	options := make([]string, len(keys))
+	for i, key := range keys {
+		var value string
+		switch data := replication[key].(type) {
+		case string:
+			value = "'" + strings.Replace(data, "'", "''", -1) + "'"
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			value = fmt.Sprint(data)
+		default:
+			return "", fmt.Errorf("failed for: replication %v = %v", key, replication[key])
+		}
+		options[i] = "'" + strings.Replace(key, "'", "''", -1) + "': " + value
+	}
+
+	return "create keyspace if not exists " + name + " with replication = {" + strings.Join(options, ", ") + "}", nil
+}
+
+// ScanStruct scans the current row of rows into the struct pointed to by dest.
+// Columns are assigned to the fields with a cql tag of the column name, like `cql:"text_data"`,
+// using the same conversions as rows.Scan. Fields without a cql tag are not changed.
+// Columns without a field are an error, unless the struct has a map[string]interface{} field
+// tagged `cql:"*"` that the columns without a field are assigned to.
+func ScanStruct(rows *sql.Rows, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest is not a pointer to a struct: %T", dest)
+	}
+	destValue = destValue.Elem()
+
+	fields, catchAll, err := structFields(destValue.Type())
+	if err != nil {
+		return err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	scanValues := make([]interface{}, len(columns))
+	for i := 0; i < len(columns); i++ {
+		index, ok := fields[columns[i]]
+		if ok {
+			scanValues[i] = destValue.FieldByIndex(index).Addr().Interface()
+			continue
+		}
+		if catchAll == nil {
+			return fmt.Errorf("no struct field for column: %v", columns[i])
+		}
+		scanValues[i] = new(interface{})
+	}
+
+	err = rows.Scan(scanValues...)
+	if err != nil {
+		return err
+	}
+
+	if catchAll != nil {
+		catchAllValue := destValue.FieldByIndex(catchAll)
+		if catchAllValue.IsNil() {
+			catchAllValue.Set(reflect.MakeMap(catchAllValue.Type()))
+		}
+		for i := 0; i < len(columns); i++ {
+			if _, ok := fields[columns[i]]; !ok {
+				catchAllValue.SetMapIndex(reflect.ValueOf(columns[i]), reflect.ValueOf(scanValues[i]).Elem())
+			}
+		}
+	}
+
+	return nil
+}
+
+// structFields returns the field indexes of a struct type by cql tag column name,
+// and the index of the catch-all field tagged `cql:"*"`, nil when there is none.
+// Fields of embedded structs are included, fields of the struct take precedence over them.
+func structFields(structType reflect.Type) (map[string][]int, []int, error) {
+	fields := make(map[string][]int)
+	var catchAll []int
+	var embedded []int
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		column, ok := field.Tag.Lookup("cql")
+		if !ok {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				embedded = append(embedded, i)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			return nil, nil, fmt.Errorf("cql tag on unexported field: %v", field.Name)
+		}
+		if column == "*" {
+			if field.Type != reflect.TypeOf(map[string]interface{}(nil)) {
+				return nil, nil, fmt.Errorf("cql tag * field is not map[string]interface{}: %v", field.Name)
+			}
+			catchAll = field.Index
+			continue
+		}
+		if _, ok := fields[column]; ok {
+			return nil, nil, fmt.Errorf("duplicate cql tag: %v", column)
+		}
+		fields[column] = field.Index
+	}
+
+	for _, i := range embedded {
+		embeddedFields, embeddedCatchAll, err := structFields(structType.Field(i).Type)
+		if err != nil {
+			return nil, nil, err
+		}
+		for column, index := range embeddedFields {
+			if _, ok := fields[column]; !ok {
+				fields[column] = append([]int{i}, index...)
+			}
+		}
+		if catchAll == nil && embeddedCatchAll != nil {
+			catchAll = append([]int{i}, embeddedCatchAll...)
+		}
+	}
+
+	return fields, catchAll, nil
+}
+
+// emptyCollection sets a nil list or set pointed to by collection to an empty slice.
+// CQL does not store empty lists or sets, they are returned as null. Null maps are left as nil.
+func emptyCollection(collection interface{}) {
+	value := reflect.ValueOf(collection).Elem()
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		value.Set(reflect.MakeSlice(value.Type(), 0, 0))
+	}
+}
+
+// Scan implements sql.Scanner, scanning the tuple elements into the tuple pointers
+func (tuple Tuple) Scan(src interface{}) error {
+	values, ok := src.([]interface{})
+	if !ok {
+		return fmt.Errorf("tuple source is not []interface{}: %T", src)
+	}
+	if len(values) != len(tuple) {
+		return fmt.Errorf("tuple has %v elements, destination has %v", len(values), len(tuple))
+	}
+
+	for i := 0; i < len(tuple); i++ {
+		dest := reflect.ValueOf(tuple[i])
+		if dest.Kind() != reflect.Ptr || dest.IsNil() {
+			return fmt.Errorf("tuple destination %v is not a pointer", i)
+		}
+		dest = dest.Elem()
+		if values[i] == nil {
+			dest.Set(reflect.Zero(dest.Type()))
+			continue
+		}
+
+		source := reflect.ValueOf(values[i])
+		switch {
+		case source.Type().AssignableTo(dest.Type()):
+			dest.Set(source)
+		case isNumberKind(source.Kind()) && isNumberKind(dest.Kind()):
+			dest.Set(source.Convert(dest.Type()))
+		default:
+			return fmt.Errorf("tuple element %v of type %T can not be scanned into %T", i, values[i], tuple[i])
+		}
+	}
+
+	return nil
+}
+
+// isNumberKind returns true if kind is an integer or float kind
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// columnValue converts a column value from gocql to the driver value for the column type.
+// Uuid, timeuuid, decimal, and varint are strings in canonical form so they can be scanned into *string and sql.Scanner types.
+// Inet is a net.IP so it can be scanned into *net.IP, IPv4 addresses are 4 bytes.
+func columnValue(typeInfo gocql.TypeInfo, value driver.Value) driver.Value {
+	if typeInfo == nil {
+		return value
+	}
+	switch typeInfo.Type() {
+	case gocql.TypeUUID, gocql.TypeTimeUUID:
+		if uuid, ok := value.(gocql.UUID); ok {
+			return uuid.String()
+		}
+	case gocql.TypeInet:
+		if text, ok := value.(string); ok {
+			ip := net.ParseIP(text)
+			if ip == nil {
+				return value
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				return ip4
+			}
+			return ip
+		}
+	case gocql.TypeDecimal:
+		if dec, ok := value.(*inf.Dec); ok {
+			if dec == nil {
+				return nil
+			}
+			return dec.String()
+		}
+	case gocql.TypeVarint:
+		if bigInt, ok := value.(*big.Int); ok {
+			if bigInt == nil {
+				return nil
+			}
+			return bigInt.String()
+		}
+	}
+	return value
+}
+
+// Scan implements sql.Scanner, scanning a uuid into a gocql.UUID
+func (uuid *UUID) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*uuid = UUID{}
+	case string:
+		cqlUUID, err := gocql.ParseUUID(value)
+		if err != nil {
+			return err
+		}
+		*uuid = UUID(cqlUUID)
+	case []byte:
+		cqlUUID, err := gocql.UUIDFromBytes(value)
+		if err != nil {
+			return err
+		}
+		*uuid = UUID(cqlUUID)
+	case gocql.UUID:
+		*uuid = UUID(value)
+	default:
+		return fmt.Errorf("uuid source is not a string: %T", src)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner, scanning an inet into a net.IP. Null is a nil net.IP.
+func (ip *IP) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*ip = nil
+	case string:
+		if value == "" {
+			*ip = nil
+			return nil
+		}
+		netIP := net.ParseIP(value)
+		if netIP == nil {
+			return fmt.Errorf("invalid ip: %v", value)
+		}
+		*ip = IP(netIP)
+	case net.IP:
+		*ip = IP(append(net.IP(nil), value...))
+	default:
+		return fmt.Errorf("ip source is not a string: %T", src)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner, writing a blob to the Writer. Null blobs write nothing.
+func (blobWriter *BlobWriter) Scan(src interface{}) error {
+	if blobWriter.Writer == nil {
+		return fmt.Errorf("blob writer Writer is nil")
+	}
+	switch value := src.(type) {
+	case nil:
+	case []byte:
+		_, err := blobWriter.Writer.Write(value)
+		return err
+	case string:
+		_, err := io.WriteString(blobWriter.Writer, value)
+		return err
+	default:
+		return fmt.Errorf("blob source is not a []byte: %T", src)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner, scanning a varint into a big.Int
+func (varint *Varint) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		(*big.Int)(varint).SetInt64(0)
+	case string:
+		_, ok := (*big.Int)(varint).SetString(value, 10)
+		if !ok {
+			return fmt.Errorf("invalid varint: %v", value)
+		}
+	case *big.Int:
+		(*big.Int)(varint).Set(value)
+	case int64:
+		(*big.Int)(varint).SetInt64(value)
+	default:
+		return fmt.Errorf("varint source is not a string: %T", src)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner, scanning a decimal into an inf.Dec
+func (decimal *Decimal) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		(*inf.Dec)(decimal).SetUnscaled(0).SetScale(0)
+	case string:
+		_, ok := (*inf.Dec)(decimal).SetString(value)
+		if !ok {
+			return fmt.Errorf("invalid decimal: %v", value)
+		}
+	case *inf.Dec:
+		(*inf.Dec)(decimal).Set(value)
+	default:
+		return fmt.Errorf("decimal source is not a string: %T", src)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner, scanning a decimal or varint into a big.Float
+func (bigFloat *BigFloat) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		(*big.Float)(bigFloat).SetInt64(0)
+	case string:
+		_, ok := (*big.Float)(bigFloat).SetString(value)
+		if !ok {
+			return fmt.Errorf("invalid big float: %v", value)
+		}
+	case *inf.Dec:
+		_, ok := (*big.Float)(bigFloat).SetString(value.String())
+		if !ok {
+			return fmt.Errorf("invalid big float: %v", value)
+		}
+	case *big.Int:
+		(*big.Float)(bigFloat).SetInt(value)
+	case float64:
+		(*big.Float)(bigFloat).SetFloat64(value)
+	default:
+		return fmt.Errorf("big float source is not a string: %T", src)
+	}
+	return nil
+}
+
+// Challenge returns the PLAIN mechanism for the DseAuthenticator, otherwise the username and password
+func (dsePlainTextAuthenticator DsePlainTextAuthenticator) Challenge(req []byte) ([]byte, gocql.Authenticator, error) {
+	if string(req) == "com.datastax.bdp.cassandra.auth.DseAuthenticator" {
+		return []byte("PLAIN"), dsePlainTextChallenge(dsePlainTextAuthenticator), nil
+	}
+	return dsePlainTextAuthenticator.credentials(), nil, nil
+}
+
+// Success is called on authentication success
+func (dsePlainTextAuthenticator DsePlainTextAuthenticator) Success(data []byte) error {
+	return nil
+}
+
+// credentials returns the SASL PLAIN credentials, an empty authorization id, username, and password separated by zero bytes
+func (dsePlainTextAuthenticator DsePlainTextAuthenticator) credentials() []byte {
+	credentials := make([]byte, 0, 2+len(dsePlainTextAuthenticator.Username)+len(dsePlainTextAuthenticator.Password))
+	credentials = append(credentials, 0)
+	credentials = append(credentials, dsePlainTextAuthenticator.Username...)
+	credentials = append(credentials, 0)
+	return append(credentials, dsePlainTextAuthenticator.Password...)
+}
+
+// Challenge returns the username and password for the PLAIN-START challenge
+func (challenge dsePlainTextChallenge) Challenge(req []byte) ([]byte, gocql.Authenticator, error) {
+	if string(req) != "PLAIN-START" {
+		return nil, nil, fmt.Errorf("unexpected dse challenge: %q", req)
+	}
+	return DsePlainTextAuthenticator(challenge).credentials(), nil, nil
+}
+
+// Success is called on authentication success
+func (challenge dsePlainTextChallenge) Success(data []byte) error {
+	return nil
+}
+
+// isFatalError returns true for errors after which the connection session should not be used
+func isFatalError(err error) bool {
+	switch err {
+	case driver.ErrBadConn, gocql.ErrSessionClosed, gocql.ErrNoConnections, gocql.ErrConnectionClosed, gocql.ErrNoConnectionsStarted:
+		return true
+	}
+	return false
+}
+
+// isNullableColumn returns true for columns scanned into a pointer to detect null.
+// Collections, user defined types, tuples, decimals, and varints are not.
+func isNullableColumn(typeInfo gocql.TypeInfo) bool {
+	if typeInfo == nil {
+		return false
+	}
+	switch typeInfo.Type() {
+	case gocql.TypeList, gocql.TypeSet, gocql.TypeMap, gocql.TypeUDT, gocql.TypeTuple,
+		gocql.TypeDecimal, gocql.TypeVarint:
+		return false
+	}
+	return true
+}
+
+// nullableToValue coverts a pointer to a pointer to driver.Value, nil for null
+func nullableToValue(sourceInterface interface{}) (driver.Value, error) {
+	source := reflect.ValueOf(sourceInterface)
+	if source.Kind() != reflect.Ptr || source.Elem().Kind() != reflect.Ptr {
+		return driver.Value(nil), fmt.Errorf("source is not a pointer to a pointer")
+	}
+	if source.Elem().IsNil() {
+		return driver.Value(nil), nil
+	}
+	return driver.Value(source.Elem().Elem().Interface()), nil
+}
+
 // interfaceToValue coverts interface to driver.Value
 func interfaceToValue(sourceInterface interface{}) (driver.Value, error) {
 	source := reflect.ValueOf(sourceInterface)